@@ -0,0 +1,127 @@
+package tracer
+
+import "math"
+
+// Bounds is an axis-aligned bounding box between a minimum and maximum
+// point.
+type Bounds struct {
+	Min Tuple
+	Max Tuple
+}
+
+// EmptyBounds returns bounds containing no points.
+func EmptyBounds() Bounds {
+	inf := math.Inf(1)
+	return Bounds{Point(inf, inf, inf), Point(-inf, -inf, -inf)}
+}
+
+// IsEmpty returns true if bounds contain no points.
+func (b Bounds) IsEmpty() bool {
+	return b.Min.x() > b.Max.x() || b.Min.y() > b.Max.y() || b.Min.z() > b.Max.z()
+}
+
+// AddPoint returns bounds grown to contain a point.
+func (b Bounds) AddPoint(p Tuple) Bounds {
+	return Bounds{
+		Point(math.Min(b.Min.x(), p.x()), math.Min(b.Min.y(), p.y()), math.Min(b.Min.z(), p.z())),
+		Point(math.Max(b.Max.x(), p.x()), math.Max(b.Max.y(), p.y()), math.Max(b.Max.z(), p.z())),
+	}
+}
+
+// Merge returns bounds grown to contain other bounds.
+func (b Bounds) Merge(b1 Bounds) Bounds {
+	if b1.IsEmpty() {
+		return b
+	}
+
+	return b.AddPoint(b1.Min).AddPoint(b1.Max)
+}
+
+// IsInfinite returns true if bounds extend infinitely along any axis.
+func (b Bounds) IsInfinite() bool {
+	for i := 0; i < 3; i++ {
+		if math.IsInf(b.Min[i], 0) || math.IsInf(b.Max[i], 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transform returns the bounds containing the eight corners of some bounds
+// transformed by a matrix. Axes along which the transformed corners are
+// undefined, such as an infinite extent rotated onto another infinite
+// extent, become unbounded.
+func (b Bounds) Transform(m Matrix) Bounds {
+	if b.IsEmpty() {
+		return b
+	}
+
+	out := EmptyBounds()
+	for _, x := range []float64{b.Min.x(), b.Max.x()} {
+		for _, y := range []float64{b.Min.y(), b.Max.y()} {
+			for _, z := range []float64{b.Min.z(), b.Max.z()} {
+				corner := Point(x, y, z)
+
+				// skip zero terms so that infinite extents are not
+				// multiplied into NaN
+				p := Point(0, 0, 0)
+				for r := 0; r < 3; r++ {
+					for c := 0; c < 4; c++ {
+						if m[r][c] != 0 {
+							p[r] += m[r][c] * corner[c]
+						}
+					}
+				}
+
+				for i := 0; i < 3; i++ {
+					if math.IsNaN(p[i]) {
+						out.Min[i] = math.Inf(-1)
+						out.Max[i] = math.Inf(1)
+						p[i] = 0
+					}
+				}
+				out = out.AddPoint(p)
+			}
+		}
+	}
+
+	return out
+}
+
+// Intersects returns true if a ray intersects bounds.
+func (b Bounds) Intersects(r Ray) bool {
+	if b.IsEmpty() {
+		return false
+	}
+
+	xtmin, xtmax := checkAxis(r.Origin.x(), r.Direction.x(), b.Min.x(), b.Max.x())
+	ytmin, ytmax := checkAxis(r.Origin.y(), r.Direction.y(), b.Min.y(), b.Max.y())
+	ztmin, ztmax := checkAxis(r.Origin.z(), r.Direction.z(), b.Min.z(), b.Max.z())
+
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+
+	return tmin <= tmax && tmax >= 0
+}
+
+// SurfaceArea returns the surface area of bounds.
+func (b Bounds) SurfaceArea() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+
+	d := b.Max.Sub(b.Min)
+	return 2 * (d.x()*d.y() + d.y()*d.z() + d.z()*d.x())
+}
+
+// Centroid returns the point at the center of bounds.
+func (b Bounds) Centroid() Tuple {
+	return b.Min.Add(b.Max).Multiply(0.5)
+}
+
+// ParentSpaceBounds returns the bounds of a shape transformed into the
+// space of its parent.
+func ParentSpaceBounds(s Shape) Bounds {
+	return s.Bounds().Transform(s.Transform())
+}
@@ -0,0 +1,201 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func boundsEqual(b, b1 Bounds, e float64) bool {
+	for i := 0; i < 3; i++ {
+		if b.Min[i] != b1.Min[i] && !eq(b.Min[i], b1.Min[i], e) {
+			return false
+		}
+		if b.Max[i] != b1.Max[i] && !eq(b.Max[i], b1.Max[i], e) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestShapeBounds(t *testing.T) {
+	type test struct {
+		shape    Shape
+		expected Bounds
+	}
+
+	inf := math.Inf(1)
+	cyl := NewCylinder()
+	cyl.SetLimits(-5, 3)
+	cone := NewCone()
+	cone.SetLimits(-5, 3)
+
+	tds := []test{
+		{NewSphere(), Bounds{Point(-1, -1, -1), Point(1, 1, 1)}},
+		{NewPlane(), Bounds{Point(-inf, 0, -inf), Point(inf, 0, inf)}},
+		{NewCube(), Bounds{Point(-1, -1, -1), Point(1, 1, 1)}},
+		{NewCylinder(), Bounds{Point(-1, -inf, -1), Point(1, inf, 1)}},
+		{cyl, Bounds{Point(-1, -5, -1), Point(1, 3, 1)}},
+		{NewCone(), Bounds{Point(-inf, -inf, -inf), Point(inf, inf, inf)}},
+		{cone, Bounds{Point(-5, -5, -5), Point(5, 3, 5)}},
+		{NewTriangle(Point(-3, 7, 2), Point(6, 2, -4), Point(2, -1, -1)),
+			Bounds{Point(-3, -1, -4), Point(6, 7, 2)}},
+	}
+
+	for i, td := range tds {
+		output := td.shape.Bounds()
+		if !boundsEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestBoundsMerge(t *testing.T) {
+	b := EmptyBounds()
+	if !b.IsEmpty() {
+		t.Errorf("expected empty bounds, returned %v", b)
+	}
+
+	b = b.AddPoint(Point(-5, 2, 0)).AddPoint(Point(7, 0, -3))
+	if !boundsEqual(b, Bounds{Point(-5, 0, -3), Point(7, 2, 0)}, epsilon) {
+		t.Errorf("expected %v, returned %v", Bounds{Point(-5, 0, -3), Point(7, 2, 0)}, b)
+	}
+
+	b = Bounds{Point(-5, -2, 0), Point(7, 4, 4)}.Merge(Bounds{Point(8, -7, -2), Point(14, 2, 8)})
+	if !boundsEqual(b, Bounds{Point(-5, -7, -2), Point(14, 4, 8)}, epsilon) {
+		t.Errorf("expected %v, returned %v", Bounds{Point(-5, -7, -2), Point(14, 4, 8)}, b)
+	}
+
+	b = b.Merge(EmptyBounds())
+	if !boundsEqual(b, Bounds{Point(-5, -7, -2), Point(14, 4, 8)}, epsilon) {
+		t.Errorf("expected %v, returned %v", Bounds{Point(-5, -7, -2), Point(14, 4, 8)}, b)
+	}
+}
+
+func TestBoundsTransform(t *testing.T) {
+	type test struct {
+		bounds   Bounds
+		m        Matrix
+		expected Bounds
+	}
+
+	inf := math.Inf(1)
+	tds := []test{
+		{Bounds{Point(-1, -1, -1), Point(1, 1, 1)},
+			RotationXMatrix(math.Pi / 4.).Multiply(RotationYMatrix(math.Pi / 4.)),
+			Bounds{Point(-1.41421, -1.70711, -1.70711), Point(1.41421, 1.70711, 1.70711)}},
+		{Bounds{Point(-1, -1, -1), Point(1, 1, 1)},
+			ShearingMatrix(ShearingOptions{XpY: 1}),
+			Bounds{Point(-2, -1, -1), Point(2, 1, 1)}},
+		{Bounds{Point(-inf, 0, -inf), Point(inf, 0, inf)},
+			TranslationMatrix(0, 2, 0),
+			Bounds{Point(-inf, 2, -inf), Point(inf, 2, inf)}},
+		{Bounds{Point(-inf, 0, -inf), Point(inf, 0, inf)},
+			RotationYMatrix(math.Pi / 4.),
+			Bounds{Point(-inf, 0, -inf), Point(inf, 0, inf)}},
+	}
+
+	for i, td := range tds {
+		output := td.bounds.Transform(td.m)
+		if !boundsEqual(output, td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestBoundsIntersects(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		expected  bool
+	}
+
+	tds := []test{
+		{Point(15, 1, 2), Vector(-1, 0, 0), true},
+		{Point(-5, -1, 4), Vector(1, 0, 0), true},
+		{Point(7, 6, 5), Vector(0, -1, 0), true},
+		{Point(9, -5, 6), Vector(0, 1, 0), true},
+		{Point(8, 2, 12), Vector(0, 0, -1), true},
+		{Point(6, 0, -5), Vector(0, 0, 1), true},
+		{Point(8, 1, 3.5), Vector(-1, 0, 0), true},
+		{Point(9, -1, -8), Vector(2, 4, 6), false},
+		{Point(8, 3, -4), Vector(6, 2, 4), false},
+		{Point(9, -1, -2), Vector(4, 6, 2), false},
+		{Point(4, 0, 9), Vector(0, 0, -1), false},
+		{Point(8, 6, -1), Vector(0, -1, 0), false},
+		{Point(12, 5, 4), Vector(-1, 0, 0), false},
+		// behind the ray
+		{Point(15, 1, 2), Vector(1, 0, 0), false},
+		// along a face or an edge
+		{Point(5, 1, -5), Vector(0, 0, 1), true},
+		{Point(5, 4, -5), Vector(0, 0, 1), true},
+	}
+
+	b := Bounds{Point(5, -2, 0), Point(11, 4, 7)}
+	for i, td := range tds {
+		output := b.Intersects(Ray{td.origin, td.direction.Normalize()})
+		if output != td.expected {
+			t.Errorf("test %d failed: expected %t, returned %t", i, td.expected, output)
+		}
+	}
+
+	if EmptyBounds().Intersects(Ray{Point(0, 0, 0), Vector(0, 0, 1)}) {
+		t.Error("expected empty bounds not to intersect, returned true")
+	}
+}
+
+func TestGroupIntersectAlongBounds(t *testing.T) {
+	// a ray along a face of the bounds is not culled
+	s := NewSphere()
+	g := NewGroup()
+	g.AddChild(s)
+
+	r := Ray{Point(0, 1, -5), Vector(0, 0, 1)}
+	if xs := Intersect(g, r); !intersectionsEqual(xs, []float64{5, 5}, s, epsilon) {
+		t.Errorf("expected intersections at 5 and 5, returned %v", xs)
+	}
+
+	// nor is a ray within flat bounds
+	tri := NewTriangle(Point(0, 0, -1), Point(1, 0, 1), Point(-1, 0, 1))
+	g = NewGroup()
+	g.AddChild(tri)
+	if !g.Bounds().Intersects(Ray{Point(0, 0, -5), Vector(0, 0, 1)}) {
+		t.Error("expected ray within flat bounds to intersect them")
+	}
+}
+
+func TestGroupBounds(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(2, 5, -3).Multiply(ScalingMatrix(2, 2, 2)))
+	c := NewCylinder()
+	c.SetLimits(-2, 2)
+	c.SetTransform(TranslationMatrix(-4, -1, 4).Multiply(ScalingMatrix(0.5, 1, 0.5)))
+
+	g := NewGroup()
+	g.AddChild(s, c)
+	expected := Bounds{Point(-4.5, -3, -5), Point(4, 7, 4.5)}
+	if !boundsEqual(g.Bounds(), expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, g.Bounds())
+	}
+
+	// bounds are recomputed when children are added to nested groups
+	outer := NewGroup()
+	outer.AddChild(g)
+	outer.Bounds()
+	s1 := NewSphere()
+	s1.SetTransform(TranslationMatrix(10, 0, 0))
+	g.AddChild(s1)
+	expected = Bounds{Point(-4.5, -3, -5), Point(11, 7, 4.5)}
+	if !boundsEqual(outer.Bounds(), expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, outer.Bounds())
+	}
+
+	// csg bounds contain both children
+	left := NewSphere()
+	right := NewSphere()
+	right.SetTransform(TranslationMatrix(2, 3, 4))
+	csg := NewCSG(CSGDifference, left, right)
+	expected = Bounds{Point(-1, -1, -1), Point(3, 4, 5)}
+	if !boundsEqual(csg.Bounds(), expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, csg.Bounds())
+	}
+}
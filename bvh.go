@@ -0,0 +1,126 @@
+package tracer
+
+import "sort"
+
+// bvhTraversalCost is the cost of testing a ray against the bounds of
+// a group, relative to intersecting a ray with a single shape.
+const bvhTraversalCost = 1.
+
+// Divide partitions the children of a group into a bounding volume
+// hierarchy of nested groups, so that rays only test the shapes whose
+// bounds they pass through. Groups with fewer than threshold children
+// are left unchanged. Children are split with the surface area heuristic,
+// and shapes with infinite bounds, such as planes, stay in the group.
+func (g *Group) Divide(threshold int) {
+	if len(g.children) >= threshold {
+		var finite, infinite []Shape
+		for _, c := range g.children {
+			if ParentSpaceBounds(c).IsInfinite() {
+				infinite = append(infinite, c)
+			} else {
+				finite = append(finite, c)
+			}
+		}
+
+		if left, right, ok := partitionSAH(finite); ok {
+			g.children = infinite
+			l := NewGroup()
+			l.AddChild(left...)
+			r := NewGroup()
+			r.AddChild(right...)
+			g.AddChild(l, r)
+		}
+	}
+
+	for _, c := range g.children {
+		divide(c, threshold)
+	}
+}
+
+// Divide partitions any groups among the children of a CSG shape into
+// bounding volume hierarchies.
+func (c *CSG) Divide(threshold int) {
+	divide(c.left, threshold)
+	divide(c.right, threshold)
+}
+
+// divide builds a bounding volume hierarchy within a shape, if it
+// contains other shapes.
+func divide(s Shape, threshold int) {
+	switch s := s.(type) {
+	case *Group:
+		s.Divide(threshold)
+	case *CSG:
+		s.Divide(threshold)
+	}
+}
+
+// partitionSAH splits shapes in two along the axis and position that
+// minimizes the surface area heuristic cost, returning false if no split
+// is cheaper than intersecting every shape.
+func partitionSAH(shapes []Shape) ([]Shape, []Shape, bool) {
+	n := len(shapes)
+	if n < 2 {
+		return nil, nil, false
+	}
+
+	bounds := make([]Bounds, n)
+	parent := EmptyBounds()
+	for i, s := range shapes {
+		bounds[i] = ParentSpaceBounds(s)
+		parent = parent.Merge(bounds[i])
+	}
+
+	// the cost of leaving every shape in the parent group
+	bestCost := float64(n)
+	bestAxis, bestSplit := -1, 0
+	parentArea := parent.SurfaceArea()
+	if parentArea == 0 {
+		return nil, nil, false
+	}
+
+	order := make([][]int, 3)
+	rightAreas := make([]float64, n)
+	for axis := 0; axis < 3; axis++ {
+		order[axis] = make([]int, n)
+		for i := range order[axis] {
+			order[axis][i] = i
+		}
+		sort.SliceStable(order[axis], func(i, j int) bool {
+			return bounds[order[axis][i]].Centroid()[axis] < bounds[order[axis][j]].Centroid()[axis]
+		})
+
+		// sweep from the right, then from the left, to find the area of
+		// the shapes on each side of every split
+		b := EmptyBounds()
+		for i := n - 1; i > 0; i-- {
+			b = b.Merge(bounds[order[axis][i]])
+			rightAreas[i] = b.SurfaceArea()
+		}
+
+		b = EmptyBounds()
+		for i := 1; i < n; i++ {
+			b = b.Merge(bounds[order[axis][i-1]])
+			cost := bvhTraversalCost +
+				(b.SurfaceArea()*float64(i)+rightAreas[i]*float64(n-i))/parentArea
+			if cost < bestCost {
+				bestCost, bestAxis, bestSplit = cost, axis, i
+			}
+		}
+	}
+
+	if bestAxis < 0 {
+		return nil, nil, false
+	}
+
+	left := make([]Shape, 0, bestSplit)
+	for _, i := range order[bestAxis][:bestSplit] {
+		left = append(left, shapes[i])
+	}
+	right := make([]Shape, 0, n-bestSplit)
+	for _, i := range order[bestAxis][bestSplit:] {
+		right = append(right, shapes[i])
+	}
+
+	return left, right, true
+}
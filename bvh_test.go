@@ -0,0 +1,138 @@
+package tracer
+
+import "testing"
+
+func TestPartitionSAH(t *testing.T) {
+	// two clusters of spheres are split apart
+	s1 := NewSphere()
+	s1.SetTransform(TranslationMatrix(-10, 0, 0))
+	s2 := NewSphere()
+	s2.SetTransform(TranslationMatrix(10, 0, 0))
+	s3 := NewSphere()
+	s3.SetTransform(TranslationMatrix(-10, 2, 0))
+	s4 := NewSphere()
+	s4.SetTransform(TranslationMatrix(10, 2, 0))
+	g := NewGroup()
+	g.AddChild(s1, s2, s3, s4)
+
+	left, right, ok := partitionSAH(g.Children())
+	if !ok {
+		t.Error("expected split, returned none")
+		return
+	}
+	if len(left) != 2 || len(right) != 2 || !includesAll(left, s1, s3) || !includesAll(right, s2, s4) {
+		t.Errorf("expected [s1 s3] [s2 s4], returned %v %v", left, right)
+	}
+
+	// overlapping shapes are not worth splitting
+	g = NewGroup()
+	g.AddChild(NewSphere(), NewSphere())
+	if _, _, ok := partitionSAH(g.Children()); ok {
+		t.Error("expected no split, returned split")
+	}
+}
+
+func TestGroupDivide(t *testing.T) {
+	// a grid of spheres and a plane
+	g := NewGroup()
+	var spheres []Shape
+	for x := -5; x < 5; x++ {
+		for y := -5; y < 5; y++ {
+			s := NewSphere()
+			s.SetTransform(TranslationMatrix(float64(3*x), float64(3*y), 0))
+			spheres = append(spheres, s)
+		}
+	}
+	p := NewPlane()
+	p.SetTransform(TranslationMatrix(0, -20, 0))
+	g.AddChild(spheres...)
+	g.AddChild(p)
+
+	// the same rays hit the same shapes before and after dividing
+	var rays []Ray
+	for x := -16.; x < 16; x += 0.7 {
+		for y := -16.; y < 16; y += 0.7 {
+			rays = append(rays, Ray{Point(x, y, -10), Vector(0.01, -0.02, 1).Normalize()})
+		}
+	}
+	before := make([]Intersections, len(rays))
+	for i, r := range rays {
+		before[i] = Intersect(g, r)
+	}
+
+	g.Divide(4)
+
+	if len(g.Children()) != 3 || g.Children()[0] != p {
+		t.Errorf("expected plane and two subgroups, returned %v", g.Children())
+	}
+	for _, c := range g.Children()[1:] {
+		if _, ok := c.(*Group); !ok {
+			t.Errorf("expected subgroup, returned %T", c)
+		}
+	}
+
+	for i, r := range rays {
+		output := Intersect(g, r)
+		if len(output) != len(before[i]) {
+			t.Errorf("test %d failed: expected %d intersections, returned %d", i, len(before[i]), len(output))
+			continue
+		}
+		for j := range output {
+			if output[j] != before[i][j] {
+				t.Errorf("test %d failed: expected %v, returned %v", i, before[i][j], output[j])
+			}
+		}
+	}
+
+	// the hierarchy never holds more than threshold shapes in a leaf group
+	var check func(g *Group)
+	check = func(g *Group) {
+		shapes := 0
+		for _, c := range g.Children() {
+			if sub, ok := c.(*Group); ok {
+				check(sub)
+			} else {
+				shapes++
+			}
+		}
+		if shapes >= 4 && len(g.Children()) == shapes {
+			t.Errorf("expected group to be divided, returned %d shapes", shapes)
+		}
+	}
+	check(g)
+}
+
+func TestCSGDivide(t *testing.T) {
+	left := NewGroup()
+	right := NewGroup()
+	for i := 0; i < 4; i++ {
+		s := NewSphere()
+		s.SetTransform(TranslationMatrix(float64(5*i), 0, 0))
+		left.AddChild(s)
+
+		c := NewCube()
+		c.SetTransform(TranslationMatrix(0, float64(5*i), 0))
+		right.AddChild(c)
+	}
+
+	c := NewCSG(CSGUnion, left, right)
+	c.Divide(2)
+	if len(left.Children()) != 2 || len(right.Children()) != 2 {
+		t.Errorf("expected both children to be divided, returned %d and %d children",
+			len(left.Children()), len(right.Children()))
+	}
+}
+
+// includesAll returns true if a list of shapes contains every other shape.
+func includesAll(shapes []Shape, others ...Shape) bool {
+	for _, o := range others {
+		found := false
+		for _, s := range shapes {
+			found = found || s == o
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
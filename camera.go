@@ -0,0 +1,128 @@
+package tracer
+
+import "math"
+
+// ViewTransform returns a transformation matrix orienting the world
+// relative to an eye positioned at from, looking at to, with up pointing
+// roughly upwards.
+func ViewTransform(from, to, up Tuple) Matrix {
+	forward := to.Sub(from).Normalize()
+	left := forward.Cross(up.Normalize())
+	trueUp := left.Cross(forward)
+
+	orientation := Matrix([][]float64{
+		{left.x(), left.y(), left.z(), 0},
+		{trueUp.x(), trueUp.y(), trueUp.z(), 0},
+		{-forward.x(), -forward.y(), -forward.z(), 0},
+		{0, 0, 0, 1},
+	})
+
+	return orientation.Multiply(TranslationMatrix(-from.x(), -from.y(), -from.z()))
+}
+
+// Camera maps a canvas one unit in front of an eye onto a world.
+type Camera struct {
+	hsize       int
+	vsize       int
+	fieldOfView float64
+	transform   Matrix
+	inverse     Matrix
+
+	halfWidth  float64
+	halfHeight float64
+	pixelSize  float64
+}
+
+// NewCamera creates a new camera rendering a canvas of hsize by vsize
+// pixels, with a field of view in radians and an identity transform.
+func NewCamera(hsize, vsize int, fieldOfView float64) *Camera {
+	c := &Camera{
+		hsize:       hsize,
+		vsize:       vsize,
+		fieldOfView: fieldOfView,
+		transform:   IdentityMatrix(4),
+		inverse:     IdentityMatrix(4),
+	}
+
+	// size the canvas so its longer side spans the field of view
+	halfView := math.Tan(fieldOfView / 2)
+	aspect := float64(hsize) / float64(vsize)
+	if aspect >= 1 {
+		c.halfWidth = halfView
+		c.halfHeight = halfView / aspect
+	} else {
+		c.halfWidth = halfView * aspect
+		c.halfHeight = halfView
+	}
+	c.pixelSize = (c.halfWidth * 2) / float64(hsize)
+
+	return c
+}
+
+// HSize returns the horizontal size of a camera in pixels.
+func (c *Camera) HSize() int {
+	return c.hsize
+}
+
+// VSize returns the vertical size of a camera in pixels.
+func (c *Camera) VSize() int {
+	return c.vsize
+}
+
+// FieldOfView returns the field of view of a camera in radians.
+func (c *Camera) FieldOfView() float64 {
+	return c.fieldOfView
+}
+
+// PixelSize returns the size of a pixel on the canvas of a camera.
+func (c *Camera) PixelSize() float64 {
+	return c.pixelSize
+}
+
+// Transform returns the view transformation matrix of a camera.
+func (c *Camera) Transform() Matrix {
+	return c.transform
+}
+
+// SetTransform sets the view transformation matrix of a camera, failing
+// if the matrix cannot be inverted.
+func (c *Camera) SetTransform(m Matrix) error {
+	inverse, err := m.Inverse(Epsilon)
+	if err != nil {
+		return err
+	}
+
+	c.transform = m
+	c.inverse = inverse
+	return nil
+}
+
+// RayForPixel returns the ray from a camera passing through the center
+// of the pixel at x, y.
+func (c *Camera) RayForPixel(x, y int) Ray {
+	// offset from the edge of the canvas to the pixel's center
+	xOffset := (float64(x) + 0.5) * c.pixelSize
+	yOffset := (float64(y) + 0.5) * c.pixelSize
+
+	// untransformed coordinates of the pixel in world space, with the
+	// camera looking toward -z
+	worldX := c.halfWidth - xOffset
+	worldY := c.halfHeight - yOffset
+
+	pixel := c.inverse.MultiplyT(Point(worldX, worldY, -1))
+	origin := c.inverse.MultiplyT(Point(0, 0, 0))
+
+	return Ray{Origin: origin, Direction: pixel.Sub(origin).Normalize()}
+}
+
+// Render renders a world to a canvas, casting a ray through every pixel.
+func (c *Camera) Render(w World) Canvas {
+	out := NewCanvas(c.hsize, c.vsize)
+	for y := 0; y < c.vsize; y++ {
+		for x := 0; x < c.hsize; x++ {
+			out.WritePixel(x, y, w.ColorAt(c.RayForPixel(x, y)))
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,106 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestViewTransform(t *testing.T) {
+	type test struct {
+		from     Tuple
+		to       Tuple
+		up       Tuple
+		expected Matrix
+	}
+
+	tds := []test{
+		{Point(0, 0, 0), Point(0, 0, -1), Vector(0, 1, 0), IdentityMatrix(4)},
+		{Point(0, 0, 0), Point(0, 0, 1), Vector(0, 1, 0), ScalingMatrix(-1, 1, -1)},
+		{Point(0, 0, 8), Point(0, 0, 0), Vector(0, 1, 0), TranslationMatrix(0, 0, -8)},
+		{Point(1, 3, 2), Point(4, -2, 8), Vector(1, 1, 0), Matrix([][]float64{
+			{-0.50709, 0.50709, 0.67612, -2.36643},
+			{0.76772, 0.60609, 0.12122, -2.82843},
+			{-0.35857, 0.59761, -0.71714, 0.00000},
+			{0.00000, 0.00000, 0.00000, 1.00000},
+		})},
+	}
+
+	for i, td := range tds {
+		output := ViewTransform(td.from, td.to, td.up)
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestCameraPixelSize(t *testing.T) {
+	type test struct {
+		hsize    int
+		vsize    int
+		expected float64
+	}
+
+	tds := []test{
+		{200, 125, 0.01},
+		{125, 200, 0.01},
+	}
+
+	for i, td := range tds {
+		c := NewCamera(td.hsize, td.vsize, math.Pi/2.)
+		if !eq(c.PixelSize(), td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %f, returned %f", i, td.expected, c.PixelSize())
+		}
+	}
+}
+
+func TestRayForPixel(t *testing.T) {
+	type test struct {
+		transform Matrix
+		x         int
+		y         int
+		expected  Ray
+	}
+
+	k := math.Sqrt(2) / 2.
+	tds := []test{
+		{IdentityMatrix(4), 100, 50, Ray{Point(0, 0, 0), Vector(0, 0, -1)}},
+		{IdentityMatrix(4), 0, 0, Ray{Point(0, 0, 0), Vector(0.66519, 0.33259, -0.66851)}},
+		{RotationYMatrix(math.Pi / 4.).Multiply(TranslationMatrix(0, -2, 5)), 100, 50,
+			Ray{Point(0, 2, -5), Vector(k, 0, -k)}},
+	}
+
+	for i, td := range tds {
+		c := NewCamera(201, 101, math.Pi/2.)
+		if err := c.SetTransform(td.transform); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output := c.RayForPixel(td.x, td.y)
+		if !output.Origin.Equal(td.expected.Origin, Epsilon) {
+			t.Errorf("test %d failed: expected origin %v, returned %v", i, td.expected.Origin, output.Origin)
+		}
+		if !output.Direction.Equal(td.expected.Direction, Epsilon) {
+			t.Errorf("test %d failed: expected direction %v, returned %v", i, td.expected.Direction, output.Direction)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	w := DefaultWorld()
+	c := NewCamera(11, 11, math.Pi/2.)
+	if err := c.SetTransform(ViewTransform(Point(0, 0, -5), Point(0, 0, 0), Vector(0, 1, 0))); err != nil {
+		t.Error(err)
+		return
+	}
+
+	image := c.Render(w)
+	if image.Width() != 11 || image.Height() != 11 {
+		t.Errorf("expected 11x11 canvas, returned %dx%d", image.Width(), image.Height())
+	}
+
+	output := image[5][5]
+	if !output.Equal(Color(0.38066, 0.47583, 0.2855), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.38066, 0.47583, 0.2855), output)
+	}
+}
@@ -1,8 +1,7 @@
 package tracer
 
 import (
-	"fmt"
-	"strconv"
+	"image"
 	"strings"
 )
 
@@ -32,13 +31,16 @@ func NewCanvas(width, height int) Canvas {
 	return out
 }
 
-// width is the width of a canvas.
-func (c Canvas) width() int {
+// Width is the width of a canvas.
+func (c Canvas) Width() int {
+	if len(c) == 0 {
+		return 0
+	}
 	return len(c[0])
 }
 
-// height is the height of a canvas.
-func (c Canvas) height() int {
+// Height is the height of a canvas.
+func (c Canvas) Height() int {
 	return len(c)
 }
 
@@ -47,62 +49,74 @@ func (c Canvas) WritePixel(x, y int, t Tuple) {
 	c[y][x] = t
 }
 
-// ToPPM converts a canvas to a PPM formatted strng.
-func (c Canvas) ToPPM() string {
-	// create first three lines
-	var b strings.Builder
-	fmt.Fprintf(&b, "%s\n%d %d\n%d\n", PPMFormat, c.width(), c.height(), PPMMaxColorValue)
-
-	count := 0
-	for i, row := range c {
-		for j, color := range row {
-			// add color values to string
-			for k, v := range color {
-				// scale and cutoff values
-				v *= PPMMaxColorValue
-				if v < 0 {
-					v = 0
-				}
-				if v > PPMMaxColorValue {
-					v = PPMMaxColorValue
-				}
-
-				// stringify and add to builder
-				s := strconv.Itoa(round(v))
-				l := count + len(s)
-
-				if l > PPMMaxCharacterCount {
-					fmt.Fprintf(&b, "\n%s", s)
-					count = len(s)
-
-				} else if l == PPMMaxCharacterCount {
-					fmt.Fprintf(&b, "%s\n", s)
-					count = 0
-
-				} else {
-					fmt.Fprintf(&b, "%s", s)
-					count += len(s)
-				}
-
-				// add new line or space if necessary
-				if count >= PPMMaxCharacterCount-3 {
-					fmt.Fprint(&b, "\n")
-					count = 0
-
-				} else if count != 0 && !(j == len(row)-1 && k == 2) {
-					fmt.Fprint(&b, " ")
-					count += 1
-				}
-			}
-		}
+// SubCanvas returns the part of a canvas within r, clipped to the
+// bounds of the canvas. The returned canvas shares its pixels with c.
+func (c Canvas) SubCanvas(r image.Rectangle) Canvas {
+	r = r.Intersect(c.Bounds())
+	if r.Empty() {
+		return Canvas{}
+	}
+
+	out := make(Canvas, r.Dy())
+	for y := range out {
+		out[y] = c[r.Min.Y+y][r.Min.X:r.Max.X:r.Max.X]
+	}
+	return out
+}
 
-		// start new row
-		if i != len(c)-1 {
-			fmt.Fprint(&b, "\n")
-			count = 0
+// Blit copies the pixels of src onto a canvas with the top left corner
+// of src at x, y, clipping any pixels outside the canvas. Src may share
+// pixels with c.
+func (c Canvas) Blit(src Canvas, x, y int) {
+	r := src.Bounds().Add(image.Pt(x, y)).Intersect(c.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	// copy the source rows first in case they overlap the destination
+	rows := make(Canvas, r.Dy())
+	for i := range rows {
+		rows[i] = append([]Tuple(nil), src[r.Min.Y-y+i][r.Min.X-x:r.Max.X-x]...)
+	}
+	for i, row := range rows {
+		copy(c[r.Min.Y+i][r.Min.X:], row)
+	}
+}
+
+// FlipHorizontal returns a copy of a canvas mirrored left to right.
+func (c Canvas) FlipHorizontal() Canvas {
+	out := NewCanvas(c.Width(), c.Height())
+	for y, row := range c {
+		for x, t := range row {
+			out[y][len(row)-1-x] = t
 		}
 	}
+	return out
+}
+
+// FlipVertical returns a copy of a canvas mirrored top to bottom.
+func (c Canvas) FlipVertical() Canvas {
+	out := NewCanvas(c.Width(), c.Height())
+	for y, row := range c {
+		copy(out[len(c)-1-y], row)
+	}
+	return out
+}
+
+// ToPPM converts a canvas to a PPM formatted strng. Colors are clamped
+// and written linearly, as the book's tests expect; use ToPPMEncoded for
+// tone mapped or sRGB output.
+func (c Canvas) ToPPM() string {
+	return c.ToPPMEncoded(Encoding{})
+}
+
+// ToPPMEncoded converts a canvas to a PPM formatted string, encoding its
+// colors with e.
+func (c Canvas) ToPPMEncoded(e Encoding) string {
+	var b strings.Builder
 
+	// writing to a strings.Builder cannot fail
+	c.WritePPM(&b, PPMOptions{Encoding: e})
 	return b.String()
 }
 
@@ -1,6 +1,7 @@
 package tracer
 
 import (
+	"image"
 	"math"
 	"os"
 	"testing"
@@ -131,3 +132,106 @@ const canvasPPM2 string = `P3
 153 255 204 153 255 204 153 255 204 153 255 204 153
 255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204
 153 255 204 153 255 204 153 255 204 153 255 204 153`
+
+func TestCanvasSize(t *testing.T) {
+	c := NewCanvas(5, 3)
+	if c.Width() != 5 || c.Height() != 3 {
+		t.Errorf("expected 5x3, returned %dx%d", c.Width(), c.Height())
+	}
+	if c := (Canvas{}); c.Width() != 0 || c.Height() != 0 {
+		t.Errorf("expected 0x0, returned %dx%d", c.Width(), c.Height())
+	}
+}
+
+func TestSubCanvas(t *testing.T) {
+	c := grayCanvas([][]float64{
+		{0, 1, 2, 3},
+		{4, 5, 6, 7},
+		{8, 9, 10, 11},
+	})
+
+	type test struct {
+		r        image.Rectangle
+		expected Canvas
+	}
+
+	tds := []test{
+		{image.Rect(1, 1, 3, 3), grayCanvas([][]float64{{5, 6}, {9, 10}})},
+		{image.Rect(2, -1, 10, 1), grayCanvas([][]float64{{2, 3}})},
+		{image.Rect(4, 0, 5, 1), Canvas{}},
+	}
+
+	for i, td := range tds {
+		output := c.SubCanvas(td.r)
+		if !canvasEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	// pixels are shared with the original canvas
+	c.SubCanvas(image.Rect(1, 1, 3, 3)).WritePixel(1, 0, Color(1, 2, 3))
+	if !c[1][2].Equal(Color(1, 2, 3), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(1, 2, 3), c[1][2])
+	}
+}
+
+func TestBlit(t *testing.T) {
+	type test struct {
+		x, y     int
+		expected Canvas
+	}
+
+	src := grayCanvas([][]float64{{1, 2}, {3, 4}})
+	tds := []test{
+		{1, 0, grayCanvas([][]float64{{0, 1, 2}, {0, 3, 4}, {0, 0, 0}})},
+		{-1, 2, grayCanvas([][]float64{{0, 0, 0}, {0, 0, 0}, {2, 0, 0}})},
+		{3, 0, NewCanvas(3, 3)},
+	}
+
+	for i, td := range tds {
+		c := NewCanvas(3, 3)
+		c.Blit(src, td.x, td.y)
+		if !canvasEqual(c, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, c)
+		}
+	}
+
+	// overlapping source and destination
+	c := grayCanvas([][]float64{{1, 2, 0}, {3, 4, 0}, {0, 0, 0}})
+	c.Blit(c.SubCanvas(image.Rect(0, 0, 2, 2)), 1, 1)
+	expected := grayCanvas([][]float64{{1, 2, 0}, {3, 1, 2}, {0, 3, 4}})
+	if !canvasEqual(c, expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, c)
+	}
+}
+
+func TestFlip(t *testing.T) {
+	c := grayCanvas([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	expected := grayCanvas([][]float64{{3, 2, 1}, {6, 5, 4}})
+	if output := c.FlipHorizontal(); !canvasEqual(output, expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, output)
+	}
+
+	expected = grayCanvas([][]float64{{4, 5, 6}, {1, 2, 3}})
+	if output := c.FlipVertical(); !canvasEqual(output, expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, output)
+	}
+
+	// the original canvas is unchanged
+	if !canvasEqual(c, grayCanvas([][]float64{{1, 2, 3}, {4, 5, 6}}), epsilon) {
+		t.Errorf("expected canvas to be unchanged, returned %v", c)
+	}
+}
+
+// grayCanvas creates a canvas of gray pixels from rows of values.
+func grayCanvas(rows [][]float64) Canvas {
+	out := make(Canvas, len(rows))
+	for y, row := range rows {
+		out[y] = make([]Tuple, len(row))
+		for x, v := range row {
+			out[y][x] = Color(v, v, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package tracer
+
+import "math"
+
+// Cone is a double-napped cone around the y axis of its object space,
+// with its tips meeting at the origin and a radius equal to the
+// absolute value of y. It is truncated between its minimum and maximum
+// (exclusive) and capped at both ends if Closed.
+type Cone struct {
+	shape
+	minimum float64
+	maximum float64
+	Closed  bool
+}
+
+// NewCone creates a new infinite, open cone with an identity transform
+// and the default material.
+func NewCone() *Cone {
+	return &Cone{
+		shape:   newShape(),
+		minimum: math.Inf(-1),
+		maximum: math.Inf(1),
+	}
+}
+
+// Minimum returns the lower limit of a cone on the y axis.
+func (c *Cone) Minimum() float64 {
+	return c.minimum
+}
+
+// Maximum returns the upper limit of a cone on the y axis.
+func (c *Cone) Maximum() float64 {
+	return c.maximum
+}
+
+// SetLimits truncates a cone between min and max on the y axis. The
+// bounds of any groups containing the cone are recomputed.
+func (c *Cone) SetLimits(min, max float64) {
+	c.minimum = min
+	c.maximum = max
+	invalidateBounds(c.parent)
+}
+
+// LocalIntersect returns the intersections of an object space ray with
+// the walls and caps of a cone.
+func (c *Cone) LocalIntersect(r Ray) Intersections {
+	var xs Intersections
+
+	o, d := r.Origin, r.Direction
+	a := d.x()*d.x() - d.y()*d.y() + d.z()*d.z()
+	b := 2*o.x()*d.x() - 2*o.y()*d.y() + 2*o.z()*d.z()
+	cc := o.x()*o.x() - o.y()*o.y() + o.z()*o.z()
+
+	var ts []float64
+	if math.Abs(a) < Epsilon {
+		// a ray parallel to one of the halves hits the other half once
+		if math.Abs(b) >= Epsilon {
+			ts = []float64{-cc / (2 * b)}
+		}
+	} else {
+		discriminant := b*b - 4*a*cc
+		if discriminant < 0 && discriminant > -Epsilon {
+			// rays grazing the tip produce a tiny negative discriminant
+			discriminant = 0
+		}
+		if discriminant >= 0 {
+			sqrt := math.Sqrt(discriminant)
+			ts = []float64{(-b - sqrt) / (2 * a), (-b + sqrt) / (2 * a)}
+		}
+	}
+
+	for _, t := range ts {
+		y := o.y() + t*d.y()
+		if c.minimum < y && y < c.maximum {
+			xs = xs.Add(Intersection{T: t, Object: c})
+		}
+	}
+
+	if c.Closed {
+		xs = xs.Add(intersectCaps(c, r, c.minimum, math.Abs(c.minimum), c.maximum, math.Abs(c.maximum))...)
+	}
+
+	return xs
+}
+
+// LocalNormalAt returns the normal of a cone at a point in object space,
+// which points along the y axis on the caps.
+func (c *Cone) LocalNormalAt(p Tuple, _ Intersection) Tuple {
+	dist := p.x()*p.x() + p.z()*p.z()
+
+	if dist < p.y()*p.y() && p.y() >= c.maximum-Epsilon {
+		return Vector(0, 1, 0)
+	}
+	if dist < p.y()*p.y() && p.y() <= c.minimum+Epsilon {
+		return Vector(0, -1, 0)
+	}
+
+	y := math.Sqrt(dist)
+	if p.y() > 0 {
+		y = -y
+	}
+	return Vector(p.x(), y, p.z())
+}
+
+// Bounds returns the bounds of a cone in object space.
+func (c *Cone) Bounds() Bounds {
+	limit := math.Max(math.Abs(c.minimum), math.Abs(c.maximum))
+	return Bounds{Point(-limit, c.minimum, -limit), Point(limit, c.maximum, limit)}
+}
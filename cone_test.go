@@ -0,0 +1,90 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConeIntersect(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		expected  []float64
+	}
+
+	tds := []test{
+		{Point(0, 0, -5), Vector(0, 0, 1), []float64{5, 5}},
+		{Point(0, 0, -5), Vector(1, 1, 1), []float64{8.66025, 8.66025}},
+		{Point(1, 1, -5), Vector(-0.5, -1, 1), []float64{4.55006, 49.44994}},
+		// parallel to one of the halves
+		{Point(0, 0, -1), Vector(0, 1, 1), []float64{0.35355}},
+	}
+
+	c := NewCone()
+	for i, td := range tds {
+		output := c.LocalIntersect(Ray{td.origin, td.direction.Normalize()})
+		if !intersectionsEqual(output, td.expected, c, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestConeClosed(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		count     int
+	}
+
+	tds := []test{
+		{Point(0, 0, -5), Vector(0, 1, 0), 0},
+		{Point(0, 0, -0.25), Vector(0, 1, 1), 2},
+		{Point(0, 0, -0.25), Vector(0, 1, 0), 4},
+	}
+
+	c := NewCone()
+	c.SetLimits(-0.5, 0.5)
+	c.Closed = true
+	for i, td := range tds {
+		output := c.LocalIntersect(Ray{td.origin, td.direction.Normalize()})
+		if len(output) != td.count {
+			t.Errorf("test %d failed: expected %d intersections, returned %d", i, td.count, len(output))
+		}
+	}
+}
+
+func TestConeNormalAt(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		{Point(0, 0, 0), Vector(0, 0, 0)},
+		{Point(1, 1, 1), Vector(1, -math.Sqrt(2), 1)},
+		{Point(-1, -1, 0), Vector(-1, 1, 0)},
+	}
+
+	c := NewCone()
+	for i, td := range tds {
+		output := c.LocalNormalAt(td.point, Intersection{})
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	// caps
+	c.SetLimits(-0.5, 0.5)
+	c.Closed = true
+	tds = []test{
+		{Point(0.2, 0.5, 0), Vector(0, 1, 0)},
+		{Point(0, -0.5, 0.2), Vector(0, -1, 0)},
+	}
+
+	for i, td := range tds {
+		output := c.LocalNormalAt(td.point, Intersection{})
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package tracer
+
+// CSGOperation is the operation used by a CSG shape to combine its
+// two children.
+type CSGOperation int
+
+const (
+	// CSGUnion keeps the parts of both children outside of each other.
+	CSGUnion CSGOperation = iota
+	// CSGIntersection keeps the parts of both children inside each other.
+	CSGIntersection
+	// CSGDifference keeps the parts of the left child outside of the
+	// right child, and the parts of the right child inside the left child.
+	CSGDifference
+)
+
+// CSG is a shape combining two shapes with a constructive solid geometry
+// operation. Like a group, its bounds are computed when first needed and
+// recomputed after any change to the extent of a child.
+type CSG struct {
+	shape
+	Operation CSGOperation
+	left      Shape
+	right     Shape
+	bounds    *Bounds
+}
+
+// NewCSG creates a new CSG shape combining left and right with an
+// operation, making the CSG shape their parent.
+func NewCSG(op CSGOperation, left, right Shape) *CSG {
+	c := &CSG{
+		shape:     newShape(),
+		Operation: op,
+		left:      left,
+		right:     right,
+	}
+	left.setParent(c)
+	right.setParent(c)
+
+	return c
+}
+
+// Left returns the left child of a CSG shape.
+func (c *CSG) Left() Shape {
+	return c.left
+}
+
+// Right returns the right child of a CSG shape.
+func (c *CSG) Right() Shape {
+	return c.right
+}
+
+// LocalIntersect returns the intersections of an object space ray with
+// the children of a CSG shape that lie on the surface of the combined
+// shape.
+func (c *CSG) LocalIntersect(r Ray) Intersections {
+	if !c.Bounds().Intersects(r) {
+		return nil
+	}
+
+	xs := Intersect(c.left, r).Add(Intersect(c.right, r)...)
+	return c.filterIntersections(xs)
+}
+
+// LocalNormalAt panics, since intersections are always with the children
+// of a CSG shape rather than the CSG shape itself.
+func (c *CSG) LocalNormalAt(_ Tuple, _ Intersection) Tuple {
+	panic("tracer: normal requested for a CSG shape")
+}
+
+// Bounds returns the bounds of a CSG shape in object space, which contain
+// both of its children.
+func (c *CSG) Bounds() Bounds {
+	if c.bounds == nil {
+		b := ParentSpaceBounds(c.left).Merge(ParentSpaceBounds(c.right))
+		c.bounds = &b
+	}
+
+	return *c.bounds
+}
+
+// filterIntersections returns the sorted intersections allowed by the
+// operation of a CSG shape, tracking whether each one enters or leaves
+// the left and right children.
+func (c *CSG) filterIntersections(xs Intersections) Intersections {
+	var out Intersections
+	inLeft, inRight := false, false
+
+	for _, i := range xs {
+		leftHit := includes(c.left, i.Object)
+		if c.Operation.allows(leftHit, inLeft, inRight) {
+			out = append(out, i)
+		}
+
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+
+	return out
+}
+
+// allows returns true if an intersection with the left child (leftHit)
+// or right child is kept by an operation, given whether the intersection
+// lies inside the left and right children.
+func (op CSGOperation) allows(leftHit, inLeft, inRight bool) bool {
+	switch op {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case CSGDifference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+
+	return false
+}
+
+// includes returns true if a shape is, or contains, another shape.
+func includes(s, o Shape) bool {
+	switch s := s.(type) {
+	case *Group:
+		for _, c := range s.children {
+			if includes(c, o) {
+				return true
+			}
+		}
+		return false
+	case *CSG:
+		return includes(s.left, o) || includes(s.right, o)
+	}
+
+	return s == o
+}
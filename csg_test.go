@@ -0,0 +1,179 @@
+package tracer
+
+import "testing"
+
+func TestNewCSG(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewCube()
+	c := NewCSG(CSGUnion, s1, s2)
+
+	if c.Operation != CSGUnion || c.Left() != s1 || c.Right() != s2 {
+		t.Errorf("expected union of sphere and cube, returned %v", c)
+	}
+	if s1.Parent() != c || s2.Parent() != c {
+		t.Errorf("expected children to have CSG parent, returned %v %v", s1.Parent(), s2.Parent())
+	}
+}
+
+func TestCSGAllows(t *testing.T) {
+	type test struct {
+		op       CSGOperation
+		leftHit  bool
+		inLeft   bool
+		inRight  bool
+		expected bool
+	}
+
+	tds := []test{
+		{CSGUnion, true, true, true, false},
+		{CSGUnion, true, true, false, true},
+		{CSGUnion, true, false, true, false},
+		{CSGUnion, true, false, false, true},
+		{CSGUnion, false, true, true, false},
+		{CSGUnion, false, true, false, false},
+		{CSGUnion, false, false, true, true},
+		{CSGUnion, false, false, false, true},
+		{CSGIntersection, true, true, true, true},
+		{CSGIntersection, true, true, false, false},
+		{CSGIntersection, true, false, true, true},
+		{CSGIntersection, true, false, false, false},
+		{CSGIntersection, false, true, true, true},
+		{CSGIntersection, false, true, false, true},
+		{CSGIntersection, false, false, true, false},
+		{CSGIntersection, false, false, false, false},
+		{CSGDifference, true, true, true, false},
+		{CSGDifference, true, true, false, true},
+		{CSGDifference, true, false, true, false},
+		{CSGDifference, true, false, false, true},
+		{CSGDifference, false, true, true, true},
+		{CSGDifference, false, true, false, true},
+		{CSGDifference, false, false, true, false},
+		{CSGDifference, false, false, false, false},
+	}
+
+	for i, td := range tds {
+		output := td.op.allows(td.leftHit, td.inLeft, td.inRight)
+		if output != td.expected {
+			t.Errorf("test %d failed: expected %t, returned %t", i, td.expected, output)
+		}
+	}
+}
+
+func TestCSGFilterIntersections(t *testing.T) {
+	type test struct {
+		op       CSGOperation
+		expected []int
+	}
+
+	tds := []test{
+		{CSGUnion, []int{0, 3}},
+		{CSGIntersection, []int{1, 2}},
+		{CSGDifference, []int{0, 1}},
+	}
+
+	s1 := NewSphere()
+	s2 := NewCube()
+	for i, td := range tds {
+		c := NewCSG(td.op, s1, s2)
+		xs := NewIntersections(
+			Intersection{T: 1, Object: s1},
+			Intersection{T: 2, Object: s2},
+			Intersection{T: 3, Object: s1},
+			Intersection{T: 4, Object: s2})
+
+		output := c.filterIntersections(xs)
+		if len(output) != len(td.expected) {
+			t.Errorf("test %d failed: expected %d intersections, returned %d", i, len(td.expected), len(output))
+			continue
+		}
+		for j, k := range td.expected {
+			if output[j] != xs[k] {
+				t.Errorf("test %d failed: expected %v, returned %v", i, xs[k], output[j])
+			}
+		}
+	}
+}
+
+func TestCSGIntersect(t *testing.T) {
+	// a ray misses
+	c := NewCSG(CSGUnion, NewSphere(), NewCube())
+	if xs := c.LocalIntersect(Ray{Point(0, 2, -5), Vector(0, 0, 1)}); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+
+	// a ray hits
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransform(TranslationMatrix(0, 0, 0.5))
+	c = NewCSG(CSGUnion, s1, s2)
+
+	xs := c.LocalIntersect(Ray{Point(0, 0, -5), Vector(0, 0, 1)})
+	if len(xs) != 2 {
+		t.Errorf("expected 2 intersections, returned %d", len(xs))
+		return
+	}
+	if !eq(xs[0].T, 4, epsilon) || xs[0].Object != s1 {
+		t.Errorf("expected intersection at 4 with s1, returned %v", xs[0])
+	}
+	if !eq(xs[1].T, 6.5, epsilon) || xs[1].Object != s2 {
+		t.Errorf("expected intersection at 6.5 with s2, returned %v", xs[1])
+	}
+}
+
+func TestCSGIncludes(t *testing.T) {
+	// a plate with a hole drilled through it, using a group as a child
+	plate := NewCube()
+	plate.SetTransform(ScalingMatrix(2, 0.25, 2))
+	drill := NewCylinder()
+	drill.SetLimits(-1, 1)
+	drill.Closed = true
+	drill.SetTransform(ScalingMatrix(0.5, 1, 0.5))
+	g := NewGroup()
+	g.AddChild(drill)
+	c := NewCSG(CSGDifference, plate, g)
+
+	if !includes(c, drill) || !includes(g, drill) || includes(g, plate) {
+		t.Error("expected CSG and group to include their descendants only")
+	}
+
+	// the hole lets the ray through
+	if xs := Intersect(c, Ray{Point(0, 5, 0), Vector(0, -1, 0)}); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+
+	// the plate is hit outside the hole
+	xs := Intersect(c, Ray{Point(1, 5, 0), Vector(0, -1, 0)})
+	if len(xs) != 2 || !eq(xs[0].T, 4.75, epsilon) || xs[0].Object != plate {
+		t.Errorf("expected 2 intersections with the plate, returned %v", xs)
+	}
+
+	// the walls of the hole are hit from inside the plate
+	xs = Intersect(c, Ray{Point(-5, 0, 0), Vector(1, 0, 0)})
+	expected := []Shape{plate, drill, drill, plate}
+	if len(xs) != len(expected) {
+		t.Errorf("expected %d intersections, returned %d", len(expected), len(xs))
+		return
+	}
+	for i := range expected {
+		if xs[i].Object != expected[i] {
+			t.Errorf("test %d failed: expected %v, returned %v", i, expected[i], xs[i].Object)
+		}
+	}
+}
+
+func TestCSGBoundsInvalidated(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	c := NewCSG(CSGUnion, s1, s2)
+
+	r := Ray{Point(10, 0, -5), Vector(0, 0, 1)}
+	if xs := Intersect(c, r); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+
+	// moving a child updates the bounds of the CSG shape
+	s2.SetTransform(TranslationMatrix(10, 0, 0))
+	if xs := Intersect(c, r); !intersectionsEqual(xs, []float64{4, 6}, s2, epsilon) {
+		t.Errorf("expected intersections at 4 and 6, returned %v", xs)
+	}
+}
@@ -0,0 +1,73 @@
+package tracer
+
+import "math"
+
+// Cube is an axis-aligned cube spanning -1 to 1 on every axis of its
+// object space.
+type Cube struct {
+	shape
+}
+
+// NewCube creates a new cube with an identity transform and the default
+// material.
+func NewCube() *Cube {
+	return &Cube{newShape()}
+}
+
+// LocalIntersect returns the intersections of an object space ray with
+// a cube, treating the cube as the overlap of three pairs of parallel
+// planes (slabs).
+func (c *Cube) LocalIntersect(r Ray) Intersections {
+	xtmin, xtmax := checkAxis(r.Origin.x(), r.Direction.x(), -1, 1)
+	ytmin, ytmax := checkAxis(r.Origin.y(), r.Direction.y(), -1, 1)
+	ztmin, ztmax := checkAxis(r.Origin.z(), r.Direction.z(), -1, 1)
+
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	if tmin > tmax {
+		return nil
+	}
+
+	return Intersections{{T: tmin, Object: c}, {T: tmax, Object: c}}
+}
+
+// LocalNormalAt returns the normal of the face of a cube containing a
+// point in object space.
+func (c *Cube) LocalNormalAt(p Tuple, _ Intersection) Tuple {
+	ax, ay, az := math.Abs(p.x()), math.Abs(p.y()), math.Abs(p.z())
+
+	maxc := math.Max(ax, math.Max(ay, az))
+	if maxc == ax {
+		return Vector(p.x(), 0, 0)
+	}
+	if maxc == ay {
+		return Vector(0, p.y(), 0)
+	}
+	return Vector(0, 0, p.z())
+}
+
+// Bounds returns the bounds of a cube in object space.
+func (c *Cube) Bounds() Bounds {
+	return Bounds{Point(-1, -1, -1), Point(1, 1, 1)}
+}
+
+// checkAxis returns the distances at which a ray crosses the planes at
+// min and max along a single axis, in increasing order.
+func checkAxis(origin, direction, min, max float64) (float64, float64) {
+	// a ray parallel to the slab never leaves it, or never enters it.
+	// Dividing would give NaN for an origin on either plane.
+	if direction == 0 {
+		if min <= origin && origin <= max {
+			return math.Inf(-1), math.Inf(1)
+		}
+		return math.Inf(1), math.Inf(-1)
+	}
+
+	tmin := (min - origin) / direction
+	tmax := (max - origin) / direction
+
+	if tmin > tmax {
+		return tmax, tmin
+	}
+	return tmin, tmax
+}
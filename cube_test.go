@@ -0,0 +1,67 @@
+package tracer
+
+import "testing"
+
+func TestCubeIntersect(t *testing.T) {
+	type test struct {
+		ray      Ray
+		expected []float64
+	}
+
+	tds := []test{
+		// hits each face
+		{Ray{Point(5, 0.5, 0), Vector(-1, 0, 0)}, []float64{4, 6}},
+		{Ray{Point(-5, 0.5, 0), Vector(1, 0, 0)}, []float64{4, 6}},
+		{Ray{Point(0.5, 5, 0), Vector(0, -1, 0)}, []float64{4, 6}},
+		{Ray{Point(0.5, -5, 0), Vector(0, 1, 0)}, []float64{4, 6}},
+		{Ray{Point(0.5, 0, 5), Vector(0, 0, -1)}, []float64{4, 6}},
+		{Ray{Point(0.5, 0, -5), Vector(0, 0, 1)}, []float64{4, 6}},
+		// from inside
+		{Ray{Point(0, 0.5, 0), Vector(0, 0, 1)}, []float64{-1, 1}},
+		// misses
+		{Ray{Point(-2, 0, 0), Vector(0.2673, 0.5345, 0.8018)}, nil},
+		{Ray{Point(0, -2, 0), Vector(0.8018, 0.2673, 0.5345)}, nil},
+		{Ray{Point(0, 0, -2), Vector(0.5345, 0.8018, 0.2673)}, nil},
+		{Ray{Point(2, 0, 2), Vector(0, 0, -1)}, nil},
+		{Ray{Point(0, 2, 2), Vector(0, -1, 0)}, nil},
+		{Ray{Point(2, 2, 0), Vector(-1, 0, 0)}, nil},
+		// grazes a face or an edge
+		{Ray{Point(1, 0, -5), Vector(0, 0, 1)}, []float64{4, 6}},
+		{Ray{Point(1, -1, -5), Vector(0, 0, 1)}, []float64{4, 6}},
+		{Ray{Point(1.5, 0, -5), Vector(0, 0, 1)}, nil},
+	}
+
+	c := NewCube()
+	for i, td := range tds {
+		output := c.LocalIntersect(td.ray)
+		if !intersectionsEqual(output, td.expected, c, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestCubeNormalAt(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		{Point(1, 0.5, -0.8), Vector(1, 0, 0)},
+		{Point(-1, -0.2, 0.9), Vector(-1, 0, 0)},
+		{Point(-0.4, 1, -0.1), Vector(0, 1, 0)},
+		{Point(0.3, -1, -0.7), Vector(0, -1, 0)},
+		{Point(-0.6, 0.3, 1), Vector(0, 0, 1)},
+		{Point(0.4, 0.4, -1), Vector(0, 0, -1)},
+		{Point(1, 1, 1), Vector(1, 0, 0)},
+		{Point(-1, -1, -1), Vector(-1, 0, 0)},
+	}
+
+	c := NewCube()
+	for i, td := range tds {
+		output := c.LocalNormalAt(td.point, Intersection{})
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package tracer
+
+import "math"
+
+// Cylinder is a cylinder of radius one around the y axis of its object
+// space, truncated between its minimum and maximum (exclusive) and capped
+// at both ends if Closed.
+type Cylinder struct {
+	shape
+	minimum float64
+	maximum float64
+	Closed  bool
+}
+
+// NewCylinder creates a new infinite, open cylinder with an identity
+// transform and the default material.
+func NewCylinder() *Cylinder {
+	return &Cylinder{
+		shape:   newShape(),
+		minimum: math.Inf(-1),
+		maximum: math.Inf(1),
+	}
+}
+
+// Minimum returns the lower limit of a cylinder on the y axis.
+func (c *Cylinder) Minimum() float64 {
+	return c.minimum
+}
+
+// Maximum returns the upper limit of a cylinder on the y axis.
+func (c *Cylinder) Maximum() float64 {
+	return c.maximum
+}
+
+// SetLimits truncates a cylinder between min and max on the y axis. The
+// bounds of any groups containing the cylinder are recomputed.
+func (c *Cylinder) SetLimits(min, max float64) {
+	c.minimum = min
+	c.maximum = max
+	invalidateBounds(c.parent)
+}
+
+// LocalIntersect returns the intersections of an object space ray with
+// the walls and caps of a cylinder.
+func (c *Cylinder) LocalIntersect(r Ray) Intersections {
+	var xs Intersections
+
+	// a ray parallel to the y axis can only hit the caps
+	a := r.Direction.x()*r.Direction.x() + r.Direction.z()*r.Direction.z()
+	if math.Abs(a) >= Epsilon {
+		b := 2*r.Origin.x()*r.Direction.x() + 2*r.Origin.z()*r.Direction.z()
+		cc := r.Origin.x()*r.Origin.x() + r.Origin.z()*r.Origin.z() - 1
+
+		discriminant := b*b - 4*a*cc
+		if discriminant < 0 {
+			return nil
+		}
+
+		sqrt := math.Sqrt(discriminant)
+		for _, t := range []float64{(-b - sqrt) / (2 * a), (-b + sqrt) / (2 * a)} {
+			y := r.Origin.y() + t*r.Direction.y()
+			if c.minimum < y && y < c.maximum {
+				xs = xs.Add(Intersection{T: t, Object: c})
+			}
+		}
+	}
+
+	if c.Closed {
+		xs = xs.Add(intersectCaps(c, r, c.minimum, 1, c.maximum, 1)...)
+	}
+
+	return xs
+}
+
+// LocalNormalAt returns the normal of a cylinder at a point in object
+// space, which points along the y axis on the caps.
+func (c *Cylinder) LocalNormalAt(p Tuple, _ Intersection) Tuple {
+	dist := p.x()*p.x() + p.z()*p.z()
+
+	if dist < 1 && p.y() >= c.maximum-Epsilon {
+		return Vector(0, 1, 0)
+	}
+	if dist < 1 && p.y() <= c.minimum+Epsilon {
+		return Vector(0, -1, 0)
+	}
+	return Vector(p.x(), 0, p.z())
+}
+
+// Bounds returns the bounds of a cylinder in object space.
+func (c *Cylinder) Bounds() Bounds {
+	return Bounds{Point(-1, c.minimum, -1), Point(1, c.maximum, 1)}
+}
+
+// intersectCaps returns the intersections of an object space ray with the
+// circular caps at y=min and y=max of a shape around the y axis, given
+// the radius of each cap.
+func intersectCaps(s Shape, r Ray, min, minRadius, max, maxRadius float64) Intersections {
+	// a ray parallel to the caps cannot hit them
+	if math.Abs(r.Direction.y()) < Epsilon {
+		return nil
+	}
+
+	var xs Intersections
+	for _, end := range [][2]float64{{min, minRadius}, {max, maxRadius}} {
+		t := (end[0] - r.Origin.y()) / r.Direction.y()
+		x := r.Origin.x() + t*r.Direction.x()
+		z := r.Origin.z() + t*r.Direction.z()
+		if x*x+z*z <= end[1]*end[1] {
+			xs = xs.Add(Intersection{T: t, Object: s})
+		}
+	}
+
+	return xs
+}
@@ -0,0 +1,147 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCylinderIntersect(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		expected  []float64
+	}
+
+	tds := []test{
+		// misses
+		{Point(1, 0, 0), Vector(0, 1, 0), nil},
+		{Point(0, 0, 0), Vector(0, 1, 0), nil},
+		{Point(0, 0, -5), Vector(1, 1, 1), nil},
+		// hits
+		{Point(1, 0, -5), Vector(0, 0, 1), []float64{5, 5}},
+		{Point(0, 0, -5), Vector(0, 0, 1), []float64{4, 6}},
+		{Point(0.5, 0, -5), Vector(0.1, 1, 1), []float64{6.80798, 7.08872}},
+	}
+
+	c := NewCylinder()
+	for i, td := range tds {
+		output := c.LocalIntersect(Ray{td.origin, td.direction.Normalize()})
+		if !intersectionsEqual(output, td.expected, c, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestCylinderTruncated(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		count     int
+	}
+
+	tds := []test{
+		{Point(0, 1.5, 0), Vector(0.1, 1, 0), 0},
+		{Point(0, 3, -5), Vector(0, 0, 1), 0},
+		{Point(0, 0, -5), Vector(0, 0, 1), 0},
+		{Point(0, 2, -5), Vector(0, 0, 1), 0},
+		{Point(0, 1, -5), Vector(0, 0, 1), 0},
+		{Point(0, 1.5, -2), Vector(0, 0, 1), 2},
+	}
+
+	c := NewCylinder()
+	c.SetLimits(1, 2)
+	for i, td := range tds {
+		output := c.LocalIntersect(Ray{td.origin, td.direction.Normalize()})
+		if len(output) != td.count {
+			t.Errorf("test %d failed: expected %d intersections, returned %d", i, td.count, len(output))
+		}
+	}
+}
+
+func TestCylinderClosed(t *testing.T) {
+	type test struct {
+		origin    Tuple
+		direction Tuple
+		count     int
+	}
+
+	tds := []test{
+		{Point(0, 3, 0), Vector(0, -1, 0), 2},
+		{Point(0, 3, -2), Vector(0, -1, 2), 2},
+		{Point(0, 4, -2), Vector(0, -1, 1), 2},
+		{Point(0, 0, -2), Vector(0, 1, 2), 2},
+		{Point(0, -1, -2), Vector(0, 1, 1), 2},
+	}
+
+	c := NewCylinder()
+	c.SetLimits(1, 2)
+	c.Closed = true
+	for i, td := range tds {
+		output := c.LocalIntersect(Ray{td.origin, td.direction.Normalize()})
+		if len(output) != td.count {
+			t.Errorf("test %d failed: expected %d intersections, returned %d", i, td.count, len(output))
+		}
+	}
+}
+
+func TestCylinderNormalAt(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		// walls
+		{Point(1, 0, 0), Vector(1, 0, 0)},
+		{Point(0, 5, -1), Vector(0, 0, -1)},
+		{Point(0, -2, 1), Vector(0, 0, 1)},
+		{Point(-1, 1, 0), Vector(-1, 0, 0)},
+		// caps
+		{Point(0, 1, 0), Vector(0, -1, 0)},
+		{Point(0.5, 1, 0), Vector(0, -1, 0)},
+		{Point(0, 1, 0.5), Vector(0, -1, 0)},
+		{Point(0, 2, 0), Vector(0, 1, 0)},
+		{Point(0.5, 2, 0), Vector(0, 1, 0)},
+		{Point(0, 2, 0.5), Vector(0, 1, 0)},
+	}
+
+	c := NewCylinder()
+	for i, td := range tds[:4] {
+		output := c.LocalNormalAt(td.point, Intersection{})
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	c.SetLimits(1, 2)
+	c.Closed = true
+	for i, td := range tds[4:] {
+		output := c.LocalNormalAt(td.point, Intersection{})
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i+4, td.expected, output)
+		}
+	}
+}
+
+func TestCylinderTransformed(t *testing.T) {
+	// a closed cylinder sheared and rotated like any other shape
+	c := NewCylinder()
+	c.SetLimits(0, 1)
+	c.Closed = true
+	m := RotationZMatrix(math.Pi / 2.).Multiply(ShearingMatrix(ShearingOptions{XpY: 1}))
+	if err := c.SetTransform(m); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the cap at y=1 ends up facing -x after the rotation
+	output := NormalAt(c, m.MultiplyT(Point(0.5, 1, 0)), Intersection{})
+	if !output.Equal(Vector(-1, 0, 0), Epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(-1, 0, 0), output)
+	}
+
+	xs := Intersect(c, Ray{Point(-5, 0.5, 0), Vector(1, 0, 0)})
+	if len(xs) != 2 {
+		t.Errorf("expected 2 intersections, returned %d", len(xs))
+	}
+}
@@ -0,0 +1,205 @@
+package tracer
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Dither is a method of hiding the banding caused by quantizing smooth
+// gradients to a small number of values, such as 8-bit output.
+type Dither int
+
+const (
+	// NoDither rounds each value to the nearest integer.
+	NoDither Dither = iota
+	// BayerDither adds a threshold from an 8x8 Bayer matrix before
+	// rounding, giving a regular cross-hatched pattern.
+	BayerDither
+	// FloydSteinbergDither diffuses the rounding error of each value to
+	// its neighbors to the right and below.
+	FloydSteinbergDither
+	// BlueNoiseDither adds a threshold from a tiled blue noise texture
+	// before rounding, giving an even pattern without visible structure.
+	BlueNoiseDither
+)
+
+// bayerMatrix is the 8x8 Bayer threshold matrix.
+var bayerMatrix = [8][8]int{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// blueNoiseSize is the width and height of the blue noise texture.
+const blueNoiseSize = 64
+
+var (
+	blueNoiseOnce sync.Once
+	blueNoise     []int
+)
+
+// quantizer encodes the rows of a canvas as integers, keeping the state
+// needed to dither between rows.
+type quantizer struct {
+	Encoding
+	max int
+	y   int
+	// errors diffused to the current and next rows, three per pixel
+	errs, next []float64
+}
+
+// newQuantizer creates a quantizer for rows of width pixels, encoding
+// values as integers between 0 and max.
+func (e Encoding) newQuantizer(width, max int) *quantizer {
+	q := &quantizer{Encoding: e, max: max}
+	if e.Dither == FloydSteinbergDither {
+		q.errs = make([]float64, 3*width)
+		q.next = make([]float64, 3*width)
+	}
+	if e.Dither == BlueNoiseDither {
+		blueNoiseOnce.Do(func() { blueNoise = voidAndCluster(blueNoiseSize, 1.5, 1) })
+	}
+	return q
+}
+
+// row encodes the next row of colors, writing three values per pixel
+// to out.
+func (q *quantizer) row(row []Tuple, out []int) {
+	max := float64(q.max)
+
+	for x, t := range row {
+		for k, v := range q.encode(t) {
+			switch q.Dither {
+			case BayerDither:
+				v += ditherThreshold(bayerMatrix[q.y%8][x%8], 64) / max
+
+			case BlueNoiseDither:
+				rank := blueNoise[(q.y%blueNoiseSize)*blueNoiseSize+x%blueNoiseSize]
+				v += ditherThreshold(rank, blueNoiseSize*blueNoiseSize) / max
+
+			case FloydSteinbergDither:
+				i := 3*x + k
+				v += q.errs[i] / max
+				e := v*max - float64(quantize(v, q.max))
+
+				if x+1 < len(row) {
+					q.errs[i+3] += e * 7 / 16
+					q.next[i+3] += e * 1 / 16
+				}
+				if x > 0 {
+					q.next[i-3] += e * 3 / 16
+				}
+				q.next[i] += e * 5 / 16
+			}
+
+			out[3*x+k] = quantize(v, q.max)
+		}
+	}
+
+	if q.Dither == FloydSteinbergDither {
+		q.errs, q.next = q.next, q.errs
+		for i := range q.next {
+			q.next[i] = 0
+		}
+	}
+	q.y++
+}
+
+// ditherThreshold converts a rank out of n to an offset between -0.5 and
+// 0.5 of a quantization step.
+func ditherThreshold(rank, n int) float64 {
+	return (float64(rank)+0.5)/float64(n) - 0.5
+}
+
+// voidAndCluster generates a size by size blue noise texture with
+// Ulichney's void and cluster method, returning the rank of each pixel.
+// Sigma is the standard deviation of the Gaussian filter used to find
+// clusters and voids.
+func voidAndCluster(size int, sigma float64, seed int64) []int {
+	n := size * size
+
+	// the filter wraps around the edges so that the texture tiles
+	kernel := make([]float64, n)
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			x := math.Min(float64(dx), float64(size-dx))
+			y := math.Min(float64(dy), float64(size-dy))
+			kernel[dy*size+dx] = math.Exp(-(x*x + y*y) / (2 * sigma * sigma))
+		}
+	}
+
+	// energy is the filtered sum of the set pixels around each pixel
+	set := make([]bool, n)
+	energy := make([]float64, n)
+	toggle := func(p int) {
+		set[p] = !set[p]
+		sign := 1.
+		if !set[p] {
+			sign = -1
+		}
+		px, py := p%size, p/size
+		for i := range energy {
+			dx := (i%size - px + size) % size
+			dy := (i/size - py + size) % size
+			energy[i] += sign * kernel[dy*size+dx]
+		}
+	}
+
+	// tightest returns the set pixel with the highest energy, or the
+	// unset pixel with the lowest energy
+	tightest := func(value bool) int {
+		best := -1
+		for i, e := range energy {
+			if set[i] != value {
+				continue
+			}
+			if best < 0 || (value && e > energy[best]) || (!value && e < energy[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	// start with random pixels set, then move the pixels in the tightest
+	// clusters to the largest voids until they are evenly distributed
+	initial := n / 10
+	for _, p := range rand.New(rand.NewSource(seed)).Perm(n)[:initial] {
+		toggle(p)
+	}
+	for {
+		cluster := tightest(true)
+		toggle(cluster)
+		void := tightest(false)
+		if void == cluster {
+			toggle(void)
+			break
+		}
+		toggle(void)
+	}
+	prototype := append([]bool(nil), set...)
+	saved := append([]float64(nil), energy...)
+
+	// rank the initial pixels by removing the tightest clusters, then rank
+	// the rest by filling the largest voids
+	rank := make([]int, n)
+	for r := initial - 1; r >= 0; r-- {
+		p := tightest(true)
+		toggle(p)
+		rank[p] = r
+	}
+	copy(set, prototype)
+	copy(energy, saved)
+	for r := initial; r < n; r++ {
+		p := tightest(false)
+		toggle(p)
+		rank[p] = r
+	}
+
+	return rank
+}
@@ -0,0 +1,100 @@
+package tracer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDither(t *testing.T) {
+	type test struct {
+		dither Dither
+		mean   float64
+		e      float64
+	}
+
+	// dithering keeps the average of a flat color that falls between
+	// two values
+	tds := []test{
+		{NoDither, 100, epsilon},
+		{BayerDither, 100.25, epsilon},
+		{FloydSteinbergDither, 100.25, 0.01},
+		{BlueNoiseDither, 100.25, epsilon},
+	}
+
+	c := NewCanvas(64, 64)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(100.25/255, 100.25/255, 100.25/255))
+		}
+	}
+
+	for i, td := range tds {
+		var b bytes.Buffer
+		opt := PPMOptions{Format: PPMBinaryFormat, Encoding: Encoding{Dither: td.dither}}
+		if err := c.WritePPM(&b, opt); err != nil {
+			t.Error(err)
+			return
+		}
+
+		data := b.Bytes()[len("P6\n64 64\n255\n"):]
+		sum := 0.
+		for _, v := range data {
+			if v != 100 && v != 101 {
+				t.Errorf("test %d failed: expected 100 or 101, returned %d", i, v)
+				break
+			}
+			sum += float64(v)
+		}
+		if mean := sum / float64(len(data)); !eq(mean, td.mean, td.e) {
+			t.Errorf("test %d failed: expected mean %f, returned %f", i, td.mean, mean)
+		}
+	}
+}
+
+func TestDitherBayer(t *testing.T) {
+	c := NewCanvas(8, 1)
+	for x := range c[0] {
+		c.WritePixel(x, 0, Color(0.5/255, 0, 1))
+	}
+
+	var b bytes.Buffer
+	if err := c.WritePPM(&b, PPMOptions{Encoding: Encoding{Dither: BayerDither}}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// thresholds in the first row of the matrix above 32 round up
+	expected := "P3\n8 1\n255\n0 0 255 1 0 255 0 0 255 1 0 255 0 0 255 1 0 255 0 0 255 1 0 255"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+}
+
+func TestVoidAndCluster(t *testing.T) {
+	size := 16
+	rank := voidAndCluster(size, 1.5, 1)
+
+	// every rank is used once
+	seen := make([]bool, size*size)
+	for _, r := range rank {
+		if r < 0 || r >= len(seen) || seen[r] {
+			t.Errorf("expected a permutation, returned %v", rank)
+			return
+		}
+		seen[r] = true
+	}
+
+	// the lowest ranks are spread out rather than clumped together
+	for i, r := range rank {
+		if r >= size*size/8 {
+			continue
+		}
+		x, y := i%size, i/size
+		for _, d := range [][2]int{{1, 0}, {0, 1}, {size - 1, 0}, {0, size - 1}} {
+			j := (y+d[1])%size*size + (x+d[0])%size
+			if rank[j] < size*size/8 {
+				t.Errorf("expected pixels %d and %d not to be adjacent", i, j)
+			}
+		}
+	}
+}
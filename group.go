@@ -0,0 +1,78 @@
+package tracer
+
+// Group is a shape containing other shapes, which inherit its transform.
+// The bounds of a group are computed when first needed and recomputed
+// after any change to the extent of a child.
+type Group struct {
+	shape
+	children []Shape
+	bounds   *Bounds
+}
+
+// NewGroup creates a new empty group with an identity transform.
+func NewGroup() *Group {
+	return &Group{shape: newShape()}
+}
+
+// AddChild adds shapes to a group, making the group their parent.
+func (g *Group) AddChild(children ...Shape) {
+	for _, c := range children {
+		c.setParent(g)
+		g.children = append(g.children, c)
+	}
+
+	invalidateBounds(g)
+}
+
+// invalidateBounds clears the cached bounds of a shape and any groups
+// or CSG shapes containing it, after its extent has changed.
+func invalidateBounds(s Shape) {
+	for ; s != nil; s = s.Parent() {
+		switch s := s.(type) {
+		case *Group:
+			s.bounds = nil
+		case *CSG:
+			s.bounds = nil
+		}
+	}
+}
+
+// Children returns the shapes contained in a group.
+func (g *Group) Children() []Shape {
+	return g.children
+}
+
+// LocalIntersect returns the sorted intersections of an object space ray
+// with every child of a group.
+func (g *Group) LocalIntersect(r Ray) Intersections {
+	if !g.Bounds().Intersects(r) {
+		return nil
+	}
+
+	var xs Intersections
+	for _, c := range g.children {
+		xs = xs.Add(Intersect(c, r)...)
+	}
+
+	return xs
+}
+
+// Bounds returns the bounds of a group in object space, which contain
+// every child.
+func (g *Group) Bounds() Bounds {
+	if g.bounds == nil {
+		b := EmptyBounds()
+		for _, c := range g.children {
+			b = b.Merge(ParentSpaceBounds(c))
+		}
+		g.bounds = &b
+	}
+
+	return *g.bounds
+}
+
+// LocalNormalAt panics, since intersections are always with the children
+// of a group rather than the group itself.
+func (g *Group) LocalNormalAt(_ Tuple, _ Intersection) Tuple {
+	panic("tracer: normal requested for a group")
+}
@@ -0,0 +1,181 @@
+package tracer
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGroupAddChild(t *testing.T) {
+	g := NewGroup()
+	if len(g.Children()) != 0 {
+		t.Errorf("expected empty group, returned %d children", len(g.Children()))
+	}
+	if g.Parent() != nil {
+		t.Errorf("expected no parent, returned %v", g.Parent())
+	}
+
+	s := newTestShape()
+	g.AddChild(s)
+	if len(g.Children()) != 1 || g.Children()[0] != s {
+		t.Errorf("expected group to contain shape, returned %v", g.Children())
+	}
+	if s.Parent() != g {
+		t.Errorf("expected parent to be group, returned %v", s.Parent())
+	}
+}
+
+func TestGroupIntersect(t *testing.T) {
+	// empty group
+	g := NewGroup()
+	if xs := g.LocalIntersect(Ray{Point(0, 0, 0), Vector(0, 0, 1)}); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransform(TranslationMatrix(0, 0, -3))
+	s3 := NewSphere()
+	s3.SetTransform(TranslationMatrix(5, 0, 0))
+	g.AddChild(s1, s2, s3)
+
+	xs := g.LocalIntersect(Ray{Point(0, 0, -5), Vector(0, 0, 1)})
+	expected := []Shape{s2, s2, s1, s1}
+	if len(xs) != len(expected) {
+		t.Errorf("expected %d intersections, returned %d", len(expected), len(xs))
+		return
+	}
+	for i := range expected {
+		if xs[i].Object != expected[i] {
+			t.Errorf("test %d failed: expected %v, returned %v", i, expected[i], xs[i].Object)
+		}
+	}
+
+	// transformed group
+	g = NewGroup()
+	g.SetTransform(ScalingMatrix(2, 2, 2))
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(5, 0, 0))
+	g.AddChild(s)
+
+	xs = Intersect(g, Ray{Point(10, 0, -10), Vector(0, 0, 1)})
+	if len(xs) != 2 {
+		t.Errorf("expected 2 intersections, returned %d", len(xs))
+	}
+}
+
+func TestWorldToObject(t *testing.T) {
+	g1 := NewGroup()
+	g1.SetTransform(RotationYMatrix(math.Pi / 2.))
+	g2 := NewGroup()
+	g2.SetTransform(ScalingMatrix(2, 2, 2))
+	g1.AddChild(g2)
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(5, 0, 0))
+	g2.AddChild(s)
+
+	output := WorldToObject(s, Point(-2, 0, -10))
+	if !output.Equal(Point(0, 0, -1), Epsilon) {
+		t.Errorf("expected %v, returned %v", Point(0, 0, -1), output)
+	}
+}
+
+func TestNormalToWorld(t *testing.T) {
+	g1 := NewGroup()
+	g1.SetTransform(RotationYMatrix(math.Pi / 2.))
+	g2 := NewGroup()
+	g2.SetTransform(ScalingMatrix(1, 2, 3))
+	g1.AddChild(g2)
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(5, 0, 0))
+	g2.AddChild(s)
+
+	k := math.Sqrt(3) / 3.
+	output := NormalToWorld(s, Vector(k, k, k))
+	if !output.Equal(Vector(0.2857, 0.4286, -0.8571), Epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0.2857, 0.4286, -0.8571), output)
+	}
+
+	output = NormalAt(s, Point(1.7321, 1.1547, -5.5774), Intersection{})
+	if !output.Equal(Vector(0.2857, 0.4286, -0.8571), Epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0.2857, 0.4286, -0.8571), output)
+	}
+}
+
+func TestOBJToGroup(t *testing.T) {
+	input := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	g := o.ToGroup()
+	if len(g.Children()) != 3 {
+		t.Errorf("expected 3 children, returned %d", len(g.Children()))
+		return
+	}
+	if g.Children()[0] != o.Default[0] {
+		t.Errorf("expected default triangle, returned %v", g.Children()[0])
+	}
+	for i, name := range o.GroupNames {
+		child, ok := g.Children()[i+1].(*Group)
+		if !ok {
+			t.Errorf("test %d failed: expected group, returned %T", i, g.Children()[i+1])
+			continue
+		}
+		if child.Children()[0] != o.Groups[name][0] || child.Parent() != g {
+			t.Errorf("test %d failed: expected group %s to contain its triangles", i, name)
+		}
+	}
+}
+
+func TestGroupBoundsInvalidated(t *testing.T) {
+	s := NewSphere()
+	g := NewGroup()
+	g.AddChild(s)
+	outer := NewGroup()
+	outer.AddChild(g)
+
+	r := Ray{Point(10, 0, -5), Vector(0, 0, 1)}
+	if xs := Intersect(outer, r); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+
+	// moving a child updates the bounds of every containing group
+	s.SetTransform(TranslationMatrix(10, 0, 0))
+	if xs := Intersect(outer, r); !intersectionsEqual(xs, []float64{4, 6}, s, epsilon) {
+		t.Errorf("expected intersections at 4 and 6, returned %v", xs)
+	}
+
+	// as does moving a nested group
+	g.SetTransform(TranslationMatrix(0, 10, 0))
+	r = Ray{Point(10, 10, -5), Vector(0, 0, 1)}
+	if xs := Intersect(outer, r); !intersectionsEqual(xs, []float64{4, 6}, s, epsilon) {
+		t.Errorf("expected intersections at 4 and 6, returned %v", xs)
+	}
+
+	// and changing the limits of a cylinder
+	c := NewCylinder()
+	c.SetLimits(0, 1)
+	g = NewGroup()
+	g.AddChild(c)
+	r = Ray{Point(0, 2.5, -5), Vector(0, 0, 1)}
+	if xs := Intersect(g, r); len(xs) != 0 {
+		t.Errorf("expected no intersections, returned %v", xs)
+	}
+	c.SetLimits(0, 3)
+	if xs := Intersect(g, r); !intersectionsEqual(xs, []float64{4, 6}, c, epsilon) {
+		t.Errorf("expected intersections at 4 and 6, returned %v", xs)
+	}
+}
@@ -0,0 +1,177 @@
+package tracer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// HDRFormat is the pixel format of Radiance images written by a canvas.
+const HDRFormat = "32-bit_rle_rgbe"
+
+// WriteHDR writes a canvas to w as an uncompressed Radiance RGBE (.hdr)
+// image, keeping color values above 1. Negative values are written as 0.
+func (c Canvas) WriteHDR(w io.Writer) error {
+	return writeBuffered(w, func(b *bufio.Writer) {
+		fmt.Fprintf(b, "#?RADIANCE\nFORMAT=%s\n\n-Y %d +X %d\n", HDRFormat, c.Height(), c.Width())
+
+		for _, row := range c {
+			for _, t := range row {
+				rgbe := toRGBE(t)
+				b.Write(rgbe[:])
+			}
+		}
+	})
+}
+
+// ParseHDR reads a Radiance RGBE (.hdr) image into a canvas. Scanlines
+// may be uncompressed or run-length encoded, but must run top to bottom
+// and left to right.
+func ParseHDR(r io.Reader) (Canvas, error) {
+	br := bufio.NewReader(r)
+
+	// the header is a list of lines ending with an empty line
+	magic, err := br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("HDR header: %w", err)
+	}
+	magic = strings.TrimSpace(magic)
+	if magic != "#?RADIANCE" && magic != "#?RGBE" {
+		return nil, fmt.Errorf("HDR header: unsupported format %q", magic)
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("HDR header: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT="+HDRFormat {
+			return nil, fmt.Errorf("HDR header: unsupported pixel format %q", line[len("FORMAT="):])
+		}
+	}
+
+	var width, height int
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("HDR resolution: %w", err)
+	}
+	if _, err := fmt.Sscanf(line, "-Y %d +X %d", &height, &width); err != nil {
+		return nil, fmt.Errorf("HDR resolution: unsupported orientation %q", strings.TrimSpace(line))
+	}
+
+	var scanline [][4]byte
+	return readImage("HDR", width, height, func(x, y int) (Tuple, error) {
+		if x == 0 {
+			var err error
+			if scanline, err = readHDRScanline(br, scanline, width); err != nil {
+				return nil, fmt.Errorf("HDR data: row %d: %w", y, err)
+			}
+		}
+		return fromRGBE(scanline[x]), nil
+	})
+}
+
+// readHDRScanline reads a scanline of width RGBE pixels, which is either
+// uncompressed or run-length encoded one component at a time. The pixels
+// are stored in scanline, which grows as they are read.
+func readHDRScanline(r *bufio.Reader, scanline [][4]byte, width int) ([][4]byte, error) {
+	var first [4]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return nil, errors.New("truncated scanline")
+	}
+
+	// run-length encoded scanlines start with 2, 2 and their width
+	if width < 8 || width > 0x7fff || first[0] != 2 || first[1] != 2 ||
+		int(first[2])<<8|int(first[3]) != width {
+		scanline = append(scanline[:0], first)
+		for x := 1; x < width; x++ {
+			var pixel [4]byte
+			if _, err := io.ReadFull(r, pixel[:]); err != nil {
+				return nil, errors.New("truncated scanline")
+			}
+			scanline = append(scanline, pixel)
+		}
+		return scanline, nil
+	}
+
+	// run-length encoded scanlines are at most 0x7fff pixels wide
+	if cap(scanline) < width {
+		scanline = make([][4]byte, width)
+	}
+	scanline = scanline[:width]
+
+	for k := 0; k < 4; k++ {
+		for x := 0; x < width; {
+			count, err := r.ReadByte()
+			if err != nil {
+				return nil, errors.New("truncated run")
+			}
+
+			// counts above 128 repeat a single value
+			run := count > 128
+			n := int(count)
+			if run {
+				n -= 128
+			}
+			if n == 0 || x+n > width {
+				return nil, errors.New("invalid run length")
+			}
+
+			var v byte
+			for i := 0; i < n; i++ {
+				if !run || i == 0 {
+					if v, err = r.ReadByte(); err != nil {
+						return nil, errors.New("truncated run")
+					}
+				}
+				scanline[x][k] = v
+				x++
+			}
+		}
+	}
+
+	return scanline, nil
+}
+
+// maxRGBE is the largest value representable in RGBE, with a mantissa
+// and exponent of 255.
+var maxRGBE = math.Ldexp(255, 255-(128+8))
+
+// toRGBE encodes a color as three 8-bit mantissas sharing an exponent.
+// Values are clamped between 0 and maxRGBE, and NaN is encoded as 0.
+func toRGBE(t Tuple) [4]byte {
+	limit := func(v float64) float64 {
+		if math.IsNaN(v) {
+			return 0
+		}
+		return math.Min(math.Max(v, 0), maxRGBE)
+	}
+	r, g, b := limit(t[0]), limit(t[1]), limit(t[2])
+
+	v := math.Max(r, math.Max(g, b))
+	if v < 1e-32 {
+		return [4]byte{}
+	}
+
+	m, e := math.Frexp(v)
+	scale := m * 256 / v
+	return [4]byte{byte(r * scale), byte(g * scale), byte(b * scale), byte(e + 128)}
+}
+
+// fromRGBE decodes a color from three 8-bit mantissas sharing an exponent.
+func fromRGBE(rgbe [4]byte) Tuple {
+	if rgbe[3] == 0 {
+		return Color(0, 0, 0)
+	}
+
+	// use the middle of each mantissa step, as Radiance does
+	f := math.Ldexp(1, int(rgbe[3])-(128+8))
+	return Color((float64(rgbe[0])+0.5)*f, (float64(rgbe[1])+0.5)*f, (float64(rgbe[2])+0.5)*f)
+}
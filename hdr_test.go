@@ -0,0 +1,155 @@
+package tracer
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteHDR(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.WritePixel(0, 0, Color(1, 0.5, 0))
+	c.WritePixel(1, 0, Color(-1, 6, 3))
+
+	var b bytes.Buffer
+	if err := c.WriteHDR(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 2\n" +
+		"\x80\x40\x00\x81" + "\x00\xc0\x60\x83"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+}
+
+func TestParseHDR(t *testing.T) {
+	type test struct {
+		input    string
+		expected Canvas
+	}
+
+	tds := []test{
+		// uncompressed
+		{"#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 2 +X 1\n\x80\x40\x00\x81\x00\x00\x00\x00",
+			Canvas{{Color(128.5/128, 64.5/128, 0.5/128)}, {Color(0, 0, 0)}}},
+		// run-length encoded, with other header variables
+		{"#?RGBE\n# comment\nEXPOSURE=1.0\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 8\n\x02\x02\x00\x08" +
+			"\x88\x80" + "\x88\x40" + "\x08\x00\x10\x20\x30\x40\x50\x60\x70" + "\x88\x80",
+			Canvas{{
+				Color(128.5/256, 64.5/256, 0.5/256), Color(128.5/256, 64.5/256, 16.5/256),
+				Color(128.5/256, 64.5/256, 32.5/256), Color(128.5/256, 64.5/256, 48.5/256),
+				Color(128.5/256, 64.5/256, 64.5/256), Color(128.5/256, 64.5/256, 80.5/256),
+				Color(128.5/256, 64.5/256, 96.5/256), Color(128.5/256, 64.5/256, 112.5/256),
+			}}},
+	}
+
+	for i, td := range tds {
+		output, err := ParseHDR(strings.NewReader(td.input))
+		if err != nil {
+			t.Errorf("test %d failed: %v", i, err)
+			continue
+		}
+		if !canvasEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestParseHDRRoundTrip(t *testing.T) {
+	c := NewCanvas(10, 4)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(float64(x)/3, float64(y)*10, 0.5))
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.WriteHDR(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	output, err := ParseHDR(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// mantissas are only precise to the brightest channel of each pixel
+	for y := range c {
+		for x := range c[y] {
+			e := maxComponent(c[y][x])/128 + epsilon
+			if !output[y][x].Equal(c[y][x], e) {
+				t.Errorf("pixel %d,%d failed: expected %v, returned %v", x, y, c[y][x], output[y][x])
+			}
+		}
+	}
+}
+
+func TestParseHDRRoundTripLimits(t *testing.T) {
+	c := Canvas{{Color(1e40, 0, 0), Color(math.Inf(1), math.Inf(1), math.Inf(1)), Color(math.NaN(), 1, 0)}}
+
+	var b bytes.Buffer
+	if err := c.WriteHDR(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	output, err := ParseHDR(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// large values clamp to the largest RGBE value, decoded to the
+	// middle of its mantissa step, and NaN becomes 0
+	f := math.Ldexp(1, 255-(128+8))
+	expected := Canvas{{Color(255.5*f, 0.5*f, 0.5*f), Color(255.5*f, 255.5*f, 255.5*f), Color(0, 1, 0)}}
+	for x := range expected[0] {
+		e := maxComponent(expected[0][x])/128 + epsilon
+		if !output[0][x].Equal(expected[0][x], e) {
+			t.Errorf("pixel %d failed: expected %v, returned %v", x, expected[0][x], output[0][x])
+		}
+	}
+}
+
+func TestParseHDRErrors(t *testing.T) {
+	tds := []string{
+		"",
+		"P3\n1 1\n255\n",
+		"#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x00\x00\x00\x00",
+		"#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n",
+		"#?RADIANCE\n\n+Y 1 +X 1\n\x00\x00\x00\x00",
+		"#?RADIANCE\n\n-Y 0 +X 1\n",
+		"#?RADIANCE\n\n-Y 1 +X 2\n\x00\x00\x00\x00",
+		// runs past the end of the scanline
+		"#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x89\x00",
+		"#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x00",
+		"#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x88\x00\x88",
+		// too large to allocate
+		"#?RADIANCE\n\n-Y 1 +X 1099511627776\n",
+		"#?RADIANCE\n\n-Y 1099511627776 +X 1099511627776\n",
+		// large but truncated
+		"#?RADIANCE\n\n-Y 8192 +X 8192\n\x00\x00\x00\x00",
+	}
+
+	for i, td := range tds {
+		if _, err := ParseHDR(strings.NewReader(td)); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
+
+// maxComponent returns the largest component of a color.
+func maxComponent(t Tuple) float64 {
+	m := t[0]
+	for _, v := range t[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
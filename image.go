@@ -0,0 +1,130 @@
+package tracer
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// ColorModel returns the color model of a canvas used as an image.Image.
+// The colors are clamped linear values, without tone mapping or sRGB
+// encoding; see EncodedImage for encoded colors.
+func (c Canvas) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+// Bounds returns the bounds of a canvas used as an image.Image.
+func (c Canvas) Bounds() image.Rectangle {
+	return image.Rect(0, 0, c.Width(), c.Height())
+}
+
+// At returns the color of the pixel at x, y of a canvas used as an
+// image.Image, clamping each linear channel between 0 and 1. Encoders
+// such as png.Encode therefore write the same values as WritePNG with
+// the zero Encoding.
+func (c Canvas) At(x, y int) color.Color {
+	return c.EncodedImage(Encoding{}).At(x, y)
+}
+
+// EncodedImage returns an image.Image whose colors are encoded from a
+// canvas with e, so that encoders such as png.Encode write tone mapped or
+// sRGB colors. Since pixels are read in any order, e.Dither is ignored;
+// dithering matters little at 16 bits per channel.
+func (c Canvas) EncodedImage(e Encoding) image.Image {
+	return encodedImage{c, e}
+}
+
+// encodedImage is a canvas whose colors are encoded when read.
+type encodedImage struct {
+	Canvas
+	e Encoding
+}
+
+// At returns the encoded color of the pixel at x, y.
+func (img encodedImage) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(img.Bounds())) {
+		return color.RGBA64{}
+	}
+
+	t := img.e.encode(img.Canvas[y][x])
+	return color.RGBA64{
+		R: uint16(quantize(t[0], 0xffff)),
+		G: uint16(quantize(t[1], 0xffff)),
+		B: uint16(quantize(t[2], 0xffff)),
+		A: 0xffff,
+	}
+}
+
+// FromImage creates a canvas from an image, placing the top left corner
+// of the image bounds at 0, 0. Transparent pixels are composited over
+// black, and colors are assumed to be linear, as written by WritePNG
+// with the zero Encoding.
+func FromImage(img image.Image) Canvas {
+	b := img.Bounds()
+	out := NewCanvas(b.Dx(), b.Dy())
+
+	for y := range out {
+		for x := range out[y] {
+			// colors are alpha-premultiplied, which is the same as
+			// compositing them over black
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			out[y][x] = Color(float64(r)/0xffff, float64(g)/0xffff, float64(bl)/0xffff)
+		}
+	}
+
+	return out
+}
+
+// FromImageSRGB creates a canvas from an sRGB encoded image, such as most
+// PNG and JPEG files, converting its colors to linear values. Transparent
+// pixels are composited over black.
+func FromImageSRGB(img image.Image) Canvas {
+	b := img.Bounds()
+	out := NewCanvas(b.Dx(), b.Dy())
+
+	for y := range out {
+		for x := range out[y] {
+			r, g, bl, a := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			if a == 0 {
+				continue
+			}
+
+			// remove the alpha before decoding, then composite the linear
+			// color over black
+			alpha := float64(a) / 0xffff
+			decode := func(v uint32) float64 {
+				return SRGBDecode(float64(v)/float64(a)) * alpha
+			}
+			out[y][x] = Color(decode(r), decode(g), decode(bl))
+		}
+	}
+
+	return out
+}
+
+// PNGOptions configures how a canvas is written as a PNG image.
+type PNGOptions struct {
+	// Encoding controls tone mapping, the transfer function and dithering.
+	Encoding
+}
+
+// WritePNG writes a canvas to w as an 8-bit PNG image.
+func (c Canvas) WritePNG(w io.Writer, opt PNGOptions) error {
+	img := image.NewRGBA(c.Bounds())
+	q := opt.newQuantizer(c.Width(), 0xff)
+	values := make([]int, 3*c.Width())
+	for y, row := range c {
+		q.row(row, values)
+		for x := range row {
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(values[3*x]),
+				G: uint8(values[3*x+1]),
+				B: uint8(values[3*x+2]),
+				A: 0xff,
+			})
+		}
+	}
+
+	return png.Encode(w, img)
+}
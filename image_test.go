@@ -0,0 +1,169 @@
+package tracer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// canvas implements image.Image
+var _ image.Image = Canvas{}
+
+func TestCanvasImage(t *testing.T) {
+	c := NewCanvas(3, 2)
+	c.WritePixel(0, 0, Color(1, 0.5, 0))
+	c.WritePixel(2, 1, Color(-1, 2, 0.25))
+
+	if c.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Errorf("expected %v, returned %v", image.Rect(0, 0, 3, 2), c.Bounds())
+	}
+
+	type test struct {
+		x, y     int
+		expected color.RGBA64
+	}
+
+	tds := []test{
+		{0, 0, color.RGBA64{0xffff, 0x8000, 0, 0xffff}},
+		{1, 0, color.RGBA64{0, 0, 0, 0xffff}},
+		{2, 1, color.RGBA64{0, 0xffff, 0x4000, 0xffff}},
+		// out of bounds
+		{3, 0, color.RGBA64{}},
+		{0, -1, color.RGBA64{}},
+	}
+
+	for i, td := range tds {
+		output := c.At(td.x, td.y)
+		if output != td.expected {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestEncodedImage(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.WritePixel(0, 0, Color(0.5, 2, 0.25))
+
+	img := c.EncodedImage(Encoding{SRGB: true})
+	if img.Bounds() != c.Bounds() {
+		t.Errorf("expected %v, returned %v", c.Bounds(), img.Bounds())
+	}
+
+	expected := []color.RGBA64{
+		{48192, 0xffff, 35199, 0xffff},
+		{0, 0, 0, 0xffff},
+		// out of bounds
+		{},
+	}
+	for x, e := range expected {
+		if output := img.At(x, 0); output != e {
+			t.Errorf("pixel %d failed: expected %v, returned %v", x, e, output)
+		}
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 20, 12, 21))
+	img.Set(10, 20, color.NRGBA{255, 0, 51, 255})
+	img.Set(11, 20, color.NRGBA{255, 255, 255, 0x80})
+
+	output := FromImage(img)
+	expected := Canvas{{Color(1, 0, 0.2), Color(0x8080/65535., 0x8080/65535., 0x8080/65535.)}}
+	if !canvasEqual(output, expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, output)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	c := NewCanvas(4, 3)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(float64(x)/3, float64(y)/2, 0.5))
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.WritePNG(&b, PNGOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	output := FromImage(img)
+	if !canvasEqual(output, c, 0.5/255+epsilon) {
+		t.Errorf("expected %v, returned %v", c, output)
+	}
+}
+
+func TestWritePNGEncoding(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.WritePixel(0, 0, Color(0.5, 0.2, 0))
+	c.WritePixel(1, 0, Color(3, 1, 0.0031308))
+
+	var b bytes.Buffer
+	opt := PNGOptions{Encoding{ToneMapper: ReinhardToneMapper{}, SRGB: true}}
+	if err := c.WritePNG(&b, opt); err != nil {
+		t.Error(err)
+		return
+	}
+
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []color.Color{
+		color.RGBA{156, 113, 0, 0xff},
+		color.RGBA{225, 188, 10, 0xff},
+	}
+	for x, e := range expected {
+		if output := color.RGBAModel.Convert(img.At(x, 0)); output != e {
+			t.Errorf("pixel %d failed: expected %v, returned %v", x, e, output)
+		}
+	}
+}
+
+func TestFromImageSRGB(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.NRGBA{255, 0, 188, 255})
+	img.Set(1, 0, color.NRGBA{188, 188, 188, 0x80})
+	img.Set(2, 0, color.NRGBA{255, 255, 255, 0})
+
+	output := FromImageSRGB(img)
+	a := 0x8080 / 65535.
+	v := SRGBDecode(188. / 255)
+	expected := Canvas{{Color(1, 0, v), Color(v*a, v*a, v*a), Color(0, 0, 0)}}
+	if !canvasEqual(output, expected, Epsilon) {
+		t.Errorf("expected %v, returned %v", expected, output)
+	}
+
+	// sRGB output reads back as the original linear values
+	c := NewCanvas(4, 1)
+	for x := range c[0] {
+		c.WritePixel(x, 0, Color(float64(x)/3, 0.2, 0.5))
+	}
+
+	var b bytes.Buffer
+	if err := c.WritePNG(&b, PNGOptions{Encoding{SRGB: true}}); err != nil {
+		t.Error(err)
+		return
+	}
+	decoded, err := png.Decode(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// 8-bit sRGB is most precise near black
+	if output := FromImageSRGB(decoded); !canvasEqual(output, c, 0.005) {
+		t.Errorf("expected %v, returned %v", c, output)
+	}
+}
@@ -0,0 +1,47 @@
+package tracer
+
+import "sort"
+
+// Intersection represents a ray intersecting an object at some distance t
+// along the ray. U and V locate the intersection on the surface of
+// triangles, relative to their vertices.
+type Intersection struct {
+	T      float64
+	Object Shape
+	U      float64
+	V      float64
+}
+
+// Intersections is a collection of intersections sorted by distance.
+type Intersections []Intersection
+
+// NewIntersections creates a sorted collection of intersections.
+func NewIntersections(is ...Intersection) Intersections {
+	return Intersections(nil).Add(is...)
+}
+
+// Add inserts intersections into a collection, keeping it sorted
+// by distance. The original collection may be modified.
+func (xs Intersections) Add(is ...Intersection) Intersections {
+	for _, i := range is {
+		idx := sort.Search(len(xs), func(j int) bool { return xs[j].T > i.T })
+
+		xs = append(xs, Intersection{})
+		copy(xs[idx+1:], xs[idx:])
+		xs[idx] = i
+	}
+
+	return xs
+}
+
+// Hit returns the intersection with the lowest non-negative distance,
+// returning false if every intersection lies behind the ray origin.
+func (xs Intersections) Hit() (Intersection, bool) {
+	for _, i := range xs {
+		if i.T >= 0 {
+			return i, true
+		}
+	}
+
+	return Intersection{}, false
+}
@@ -0,0 +1,65 @@
+package tracer
+
+import "testing"
+
+func TestIntersections(t *testing.T) {
+	s := NewSphere()
+	i1 := Intersection{T: 5, Object: s}
+	i2 := Intersection{T: 7, Object: s}
+	i3 := Intersection{T: -3, Object: s}
+	i4 := Intersection{T: 2, Object: s}
+
+	xs := NewIntersections(i1, i2, i3, i4)
+	if !intersectionsEqual(xs, []float64{-3, 2, 5, 7}, s, epsilon) {
+		t.Errorf("expected sorted intersections, returned %v", xs)
+	}
+
+	xs = xs.Add(Intersection{T: 6, Object: s}, Intersection{T: -4, Object: s})
+	if !intersectionsEqual(xs, []float64{-4, -3, 2, 5, 6, 7}, s, epsilon) {
+		t.Errorf("expected sorted intersections, returned %v", xs)
+	}
+}
+
+func TestHit(t *testing.T) {
+	s := NewSphere()
+
+	type test struct {
+		xs       Intersections
+		expected float64
+		ok       bool
+	}
+
+	tds := []test{
+		{NewIntersections(Intersection{T: 1, Object: s}, Intersection{T: 2, Object: s}), 1, true},
+		{NewIntersections(Intersection{T: -1, Object: s}, Intersection{T: 1, Object: s}), 1, true},
+		{NewIntersections(Intersection{T: -2, Object: s}, Intersection{T: -1, Object: s}), 0, false},
+		{NewIntersections(Intersection{T: 5, Object: s}, Intersection{T: 7, Object: s}, Intersection{T: -3, Object: s}, Intersection{T: 2, Object: s}), 2, true},
+		{nil, 0, false},
+	}
+
+	for i, td := range tds {
+		output, ok := td.xs.Hit()
+		if ok != td.ok {
+			t.Errorf("test %d failed: expected %t, returned %t", i, td.ok, ok)
+			continue
+		}
+		if ok && output.T != td.expected {
+			t.Errorf("test %d failed: expected %f, returned %f", i, td.expected, output.T)
+		}
+	}
+}
+
+// intersectionsEqual returns true if a collection of intersections has
+// distances within some epsilon of the expected distances and each
+// intersection is with the specified object.
+func intersectionsEqual(xs Intersections, expected []float64, object Shape, e float64) bool {
+	if len(xs) != len(expected) {
+		return false
+	}
+	for i := range xs {
+		if !eq(xs[i].T, expected[i], e) || xs[i].Object != object {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package tracer
+
+import "math"
+
+// PointLight is a light source with no size at a single point in space.
+type PointLight struct {
+	Position  Tuple
+	Intensity Tuple
+}
+
+// Lighting computes the color of a material on an object at a point
+// illuminated by a light, using the Phong reflection model. A point in
+// shadow only receives ambient light.
+func Lighting(m Material, object Shape, l PointLight, point, eyev, normalv Tuple, inShadow bool) Tuple {
+	color := m.Color
+	if m.Pattern != nil {
+		color = PatternAtShape(m.Pattern, object, point)
+	}
+
+	// combine surface color with the light's color
+	effectiveColor := color.Product(l.Intensity)
+
+	// find the direction to the light source
+	lightv := l.Position.Sub(point).Normalize()
+
+	ambient := effectiveColor.Multiply(m.Ambient)
+	if inShadow {
+		return ambient
+	}
+
+	diffuse := Color(0, 0, 0)
+	specular := Color(0, 0, 0)
+
+	// a negative cosine means the light is on the other side of the surface
+	lightDotNormal := lightv.Dot(normalv)
+	if lightDotNormal >= 0 {
+		diffuse = effectiveColor.Multiply(m.Diffuse * lightDotNormal)
+
+		// a negative cosine means the light reflects away from the eye
+		reflectv := lightv.Negate().Reflect(normalv)
+		reflectDotEye := reflectv.Dot(eyev)
+		if reflectDotEye > 0 {
+			factor := math.Pow(reflectDotEye, m.Shininess)
+			specular = l.Intensity.Multiply(m.Specular * factor)
+		}
+	}
+
+	return ambient.Add(diffuse).Add(specular)
+}
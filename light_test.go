@@ -0,0 +1,45 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLighting(t *testing.T) {
+	type test struct {
+		eyev     Tuple
+		light    PointLight
+		expected Tuple
+	}
+
+	k := math.Sqrt(2) / 2.
+	tds := []test{
+		// eye between the light and the surface
+		{Vector(0, 0, -1), PointLight{Point(0, 0, -10), Color(1, 1, 1)}, Color(1.9, 1.9, 1.9)},
+		// eye offset 45 degrees
+		{Vector(0, k, -k), PointLight{Point(0, 0, -10), Color(1, 1, 1)}, Color(1.0, 1.0, 1.0)},
+		// light offset 45 degrees
+		{Vector(0, 0, -1), PointLight{Point(0, 10, -10), Color(1, 1, 1)}, Color(0.7364, 0.7364, 0.7364)},
+		// eye in the path of the reflection vector
+		{Vector(0, -k, -k), PointLight{Point(0, 10, -10), Color(1, 1, 1)}, Color(1.6364, 1.6364, 1.6364)},
+		// light behind the surface
+		{Vector(0, 0, -1), PointLight{Point(0, 0, 10), Color(1, 1, 1)}, Color(0.1, 0.1, 0.1)},
+	}
+
+	m := DefaultMaterial()
+	p := Point(0, 0, 0)
+	normalv := Vector(0, 0, -1)
+
+	for i, td := range tds {
+		output := Lighting(m, NewSphere(), td.light, p, td.eyev, normalv, false)
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	// surface in shadow
+	output := Lighting(m, NewSphere(), PointLight{Point(0, 0, -10), Color(1, 1, 1)}, p, Vector(0, 0, -1), normalv, true)
+	if !output.Equal(Color(0.1, 0.1, 0.1), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.1, 0.1, 0.1), output)
+	}
+}
@@ -0,0 +1,33 @@
+package tracer
+
+// Material represents the surface properties of an object used by the
+// Phong reflection model. If Pattern is set, it is used instead of Color.
+// Reflective ranges from 0 for a matte surface to 1 for a perfect mirror,
+// and Transparency from 0 for an opaque surface to 1 for a clear one.
+// RefractiveIndex determines how much light bends entering the material,
+// from 1 for a vacuum to around 1.5 for glass.
+type Material struct {
+	Color           Tuple
+	Pattern         Pattern
+	Ambient         float64
+	Diffuse         float64
+	Specular        float64
+	Shininess       float64
+	Reflective      float64
+	Transparency    float64
+	RefractiveIndex float64
+}
+
+// DefaultMaterial returns a white material with default reflection values.
+func DefaultMaterial() Material {
+	return Material{
+		Color:           Color(1, 1, 1),
+		Ambient:         0.1,
+		Diffuse:         0.9,
+		Specular:        0.9,
+		Shininess:       200,
+		Reflective:      0,
+		Transparency:    0,
+		RefractiveIndex: 1,
+	}
+}
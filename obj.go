@@ -0,0 +1,202 @@
+package tracer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OBJ is the geometry parsed from a Wavefront OBJ file. Faces are
+// triangulated as fans; faces with vertex normals become smooth
+// triangles.
+type OBJ struct {
+	Vertices      []Tuple
+	Normals       []Tuple
+	TextureCoords []Tuple
+
+	// Default holds the triangles declared outside of any named group.
+	Default []Shape
+	// Groups holds the triangles of each named group or object, in the
+	// order the names first appear in GroupNames.
+	Groups     map[string][]Shape
+	GroupNames []string
+
+	// Ignored holds the line numbers of unrecognized statements.
+	Ignored []int
+}
+
+// ParseOBJ parses the vertices, normals, texture coordinates, faces and
+// groups of a Wavefront OBJ file, failing on malformed statements.
+// Unrecognized statements are skipped and reported in Ignored.
+func ParseOBJ(r io.Reader) (*OBJ, error) {
+	out := &OBJ{Groups: map[string][]Shape{}}
+	group := ""
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			t, err := parseOBJFloats(fields[1:], 3)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: vertex: %w", n, err)
+			}
+			out.Vertices = append(out.Vertices, Point(t[0], t[1], t[2]))
+
+		case "vn":
+			t, err := parseOBJFloats(fields[1:], 3)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: normal: %w", n, err)
+			}
+			out.Normals = append(out.Normals, Vector(t[0], t[1], t[2]))
+
+		case "vt":
+			t, err := parseOBJFloats(fields[1:], 1)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: texture coordinate: %w", n, err)
+			}
+			out.TextureCoords = append(out.TextureCoords, t)
+
+		case "f":
+			triangles, err := out.parseFace(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: face: %w", n, err)
+			}
+			if group == "" {
+				out.Default = append(out.Default, triangles...)
+			} else {
+				out.Groups[group] = append(out.Groups[group], triangles...)
+			}
+
+		case "g", "o":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: group: missing name", n)
+			}
+			group = strings.Join(fields[1:], " ")
+			if _, ok := out.Groups[group]; !ok {
+				out.Groups[group] = nil
+				out.GroupNames = append(out.GroupNames, group)
+			}
+
+		default:
+			out.Ignored = append(out.Ignored, n)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// parseFace triangulates a polygon face as a fan around its first vertex.
+func (o *OBJ) parseFace(fields []string) ([]Shape, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("expected at least 3 vertices, found %d", len(fields))
+	}
+
+	vertices := make([]Tuple, len(fields))
+	normals := make([]Tuple, len(fields))
+	for i, f := range fields {
+		// each vertex is v, v/vt, v//vn or v/vt/vn
+		refs := strings.Split(f, "/")
+		if len(refs) > 3 {
+			return nil, fmt.Errorf("invalid vertex %q", f)
+		}
+
+		idx, err := objIndex(refs[0], len(o.Vertices))
+		if err != nil {
+			return nil, fmt.Errorf("vertex %q: %w", f, err)
+		}
+		vertices[i] = o.Vertices[idx]
+
+		if len(refs) == 3 && refs[2] != "" {
+			idx, err := objIndex(refs[2], len(o.Normals))
+			if err != nil {
+				return nil, fmt.Errorf("normal %q: %w", f, err)
+			}
+			normals[i] = o.Normals[idx]
+		}
+	}
+
+	// only use vertex normals if every vertex has one
+	smooth := true
+	for _, n := range normals {
+		if n == nil {
+			smooth = false
+		}
+	}
+
+	out := make([]Shape, 0, len(fields)-2)
+	for i := 1; i < len(fields)-1; i++ {
+		if smooth {
+			out = append(out, NewSmoothTriangle(vertices[0], vertices[i], vertices[i+1],
+				normals[0], normals[i], normals[i+1]))
+		} else {
+			out = append(out, NewTriangle(vertices[0], vertices[i], vertices[i+1]))
+		}
+	}
+
+	return out, nil
+}
+
+// objIndex converts a one-based OBJ index, or a negative index relative
+// to the end of a list of size n, to a zero-based index.
+func objIndex(s string, n int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		i += n + 1
+	}
+	if i < 1 || i > n {
+		return 0, fmt.Errorf("index %s out of range", s)
+	}
+
+	return i - 1, nil
+}
+
+// parseOBJFloats parses a list of at least min floats.
+func parseOBJFloats(fields []string, min int) ([]float64, error) {
+	if len(fields) < min {
+		return nil, fmt.Errorf("expected at least %d values, found %d", min, len(fields))
+	}
+
+	out := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+// ToGroup returns a group containing the default triangles of an OBJ
+// file, and a child group for each named group.
+func (o *OBJ) ToGroup() *Group {
+	out := NewGroup()
+	out.AddChild(o.Default...)
+
+	for _, name := range o.GroupNames {
+		g := NewGroup()
+		g.AddChild(o.Groups[name]...)
+		out.AddChild(g)
+	}
+
+	return out
+}
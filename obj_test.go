@@ -0,0 +1,207 @@
+package tracer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOBJIgnored(t *testing.T) {
+	input := `There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(o.Ignored) != 5 {
+		t.Errorf("expected 5 ignored lines, returned %d", len(o.Ignored))
+	}
+}
+
+func TestParseOBJVertices(t *testing.T) {
+	input := `v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0  # comment
+v 1 1 0
+vn 0 0 1
+vn 0.707 0 -0.707
+vt 0.5 0.25`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []Tuple{Point(-1, 1, 0), Point(-1, 0.5, 0), Point(1, 0, 0), Point(1, 1, 0)}
+	if len(o.Vertices) != len(expected) {
+		t.Errorf("expected %d vertices, returned %d", len(expected), len(o.Vertices))
+		return
+	}
+	for i := range expected {
+		if !o.Vertices[i].Equal(expected[i], epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, expected[i], o.Vertices[i])
+		}
+	}
+
+	expected = []Tuple{Vector(0, 0, 1), Vector(0.707, 0, -0.707)}
+	if len(o.Normals) != len(expected) {
+		t.Errorf("expected %d normals, returned %d", len(expected), len(o.Normals))
+		return
+	}
+	for i := range expected {
+		if !o.Normals[i].Equal(expected[i], epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, expected[i], o.Normals[i])
+		}
+	}
+
+	if len(o.TextureCoords) != 1 || !o.TextureCoords[0].Equal(Tuple{0.5, 0.25}, epsilon) {
+		t.Errorf("expected %v, returned %v", []Tuple{{0.5, 0.25}}, o.TextureCoords)
+	}
+}
+
+func TestParseOBJFaces(t *testing.T) {
+	input := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3
+f 1 3 4
+f 1 2 3 4 5`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	type test struct {
+		p1, p2, p3 int
+	}
+
+	// polygons are triangulated as fans around the first vertex
+	tds := []test{{1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 3, 4}, {1, 4, 5}}
+	if len(o.Default) != len(tds) {
+		t.Errorf("expected %d triangles, returned %d", len(tds), len(o.Default))
+		return
+	}
+
+	for i, td := range tds {
+		tri, ok := o.Default[i].(*Triangle)
+		if !ok {
+			t.Errorf("test %d failed: expected triangle, returned %T", i, o.Default[i])
+			continue
+		}
+		if !tri.P1().Equal(o.Vertices[td.p1-1], epsilon) ||
+			!tri.P2().Equal(o.Vertices[td.p2-1], epsilon) ||
+			!tri.P3().Equal(o.Vertices[td.p3-1], epsilon) {
+			t.Errorf("test %d failed: expected vertices %v, returned %v %v %v", i, td, tri.P1(), tri.P2(), tri.P3())
+		}
+	}
+}
+
+func TestParseOBJGroups(t *testing.T) {
+	input := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+o SecondGroup
+f 1 3 4
+g FirstGroup
+f -4 -2 -1`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(o.Default) != 0 {
+		t.Errorf("expected no default triangles, returned %d", len(o.Default))
+	}
+	if strings.Join(o.GroupNames, ",") != "FirstGroup,SecondGroup" {
+		t.Errorf("expected FirstGroup,SecondGroup, returned %v", o.GroupNames)
+	}
+	if len(o.Groups["FirstGroup"]) != 2 || len(o.Groups["SecondGroup"]) != 1 {
+		t.Errorf("expected 2 and 1 triangles, returned %d and %d",
+			len(o.Groups["FirstGroup"]), len(o.Groups["SecondGroup"]))
+		return
+	}
+
+	// negative indices are relative to the end of the vertex list
+	tri := o.Groups["FirstGroup"][1].(*Triangle)
+	if !tri.P1().Equal(o.Vertices[0], epsilon) || !tri.P2().Equal(o.Vertices[2], epsilon) ||
+		!tri.P3().Equal(o.Vertices[3], epsilon) {
+		t.Errorf("expected vertices 1 3 4, returned %v %v %v", tri.P1(), tri.P2(), tri.P3())
+	}
+}
+
+func TestParseOBJNormals(t *testing.T) {
+	input := `v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+vt 0 0
+
+f 1//3 2//1 3//2
+f 1/1/3 2/1/1 3/1/2
+f 1/1 2/1 3/1`
+
+	o, err := ParseOBJ(strings.NewReader(input))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(o.Default) != 3 {
+		t.Errorf("expected 3 triangles, returned %d", len(o.Default))
+		return
+	}
+
+	for i, s := range o.Default[:2] {
+		tri, ok := s.(*SmoothTriangle)
+		if !ok {
+			t.Errorf("test %d failed: expected smooth triangle, returned %T", i, s)
+			continue
+		}
+		if !tri.N1().Equal(o.Normals[2], epsilon) || !tri.N2().Equal(o.Normals[0], epsilon) ||
+			!tri.N3().Equal(o.Normals[1], epsilon) {
+			t.Errorf("test %d failed: expected normals 3 1 2, returned %v %v %v", i, tri.N1(), tri.N2(), tri.N3())
+		}
+	}
+
+	if _, ok := o.Default[2].(*Triangle); !ok {
+		t.Errorf("expected triangle, returned %T", o.Default[2])
+	}
+}
+
+func TestParseOBJErrors(t *testing.T) {
+	tds := []string{
+		"v 1 2",
+		"v 1 a 3",
+		"vn 1 2",
+		"v 1 2 3\nv 1 2 3\nf 1 2",
+		"v 1 2 3\nv 1 2 3\nf 1 2 4",
+		"v 1 2 3\nv 1 2 3\nv 1 2 3\nf 1//1 2//1 3//1",
+		"v 1 2 3\nv 1 2 3\nv 1 2 3\nf 1/1/1/1 2 3",
+		"g",
+	}
+
+	for i, td := range tds {
+		if _, err := ParseOBJ(strings.NewReader(td)); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package tracer
+
+import "math"
+
+// Pattern is a function from points to colors, used to vary the color of
+// a material across the surface of a shape. Patterns define colors in
+// their own pattern space, relative to the object space of the shape.
+type Pattern interface {
+	Transform() Matrix
+	SetTransform(m Matrix) error
+
+	// PatternAt returns the color of a pattern at a point in pattern space.
+	PatternAt(p Tuple) Tuple
+
+	inverse() Matrix
+}
+
+// pattern holds the transform common to every pattern.
+type pattern struct {
+	transform        Matrix
+	transformInverse Matrix
+}
+
+// newPattern creates a pattern with an identity transform.
+func newPattern() pattern {
+	return pattern{
+		transform:        IdentityMatrix(4),
+		transformInverse: IdentityMatrix(4),
+	}
+}
+
+// Transform returns the transformation matrix of a pattern.
+func (p *pattern) Transform() Matrix {
+	return p.transform
+}
+
+// SetTransform sets the transformation matrix of a pattern, failing if
+// the matrix cannot be inverted.
+func (p *pattern) SetTransform(m Matrix) error {
+	inverse, err := m.Inverse(Epsilon)
+	if err != nil {
+		return err
+	}
+
+	p.transform = m
+	p.transformInverse = inverse
+	return nil
+}
+
+// inverse returns the cached inverse of the transformation matrix of a pattern.
+func (p *pattern) inverse() Matrix {
+	return p.transformInverse
+}
+
+// PatternAtShape returns the color of a pattern applied to a shape at
+// a point in world space.
+func PatternAtShape(pat Pattern, s Shape, p Tuple) Tuple {
+	return patternAt(pat, WorldToObject(s, p))
+}
+
+// patternAt returns the color of a pattern at a point in the space
+// containing the pattern: the object space of a shape, or the pattern
+// space of an enclosing pattern.
+func patternAt(pat Pattern, p Tuple) Tuple {
+	return pat.PatternAt(pat.inverse().MultiplyT(p))
+}
+
+// SolidPattern is a single color everywhere.
+type SolidPattern struct {
+	pattern
+	Color Tuple
+}
+
+// NewSolidPattern creates a new pattern of a single color.
+func NewSolidPattern(c Tuple) *SolidPattern {
+	return &SolidPattern{newPattern(), c}
+}
+
+// PatternAt returns the color of a solid pattern.
+func (sp *SolidPattern) PatternAt(_ Tuple) Tuple {
+	return sp.Color
+}
+
+// StripePattern alternates between two patterns along the x axis.
+type StripePattern struct {
+	pattern
+	A, B Pattern
+}
+
+// NewStripePattern creates a new stripe pattern alternating between two
+// colors every unit, starting with a at x=0.
+func NewStripePattern(a, b Tuple) *StripePattern {
+	return NewNestedStripePattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+// NewNestedStripePattern creates a new stripe pattern like NewStripePattern, but
+// between two patterns rather than colors.
+func NewNestedStripePattern(a, b Pattern) *StripePattern {
+	return &StripePattern{newPattern(), a, b}
+}
+
+// PatternAt returns the color of a stripe pattern at a point in pattern space.
+func (sp *StripePattern) PatternAt(p Tuple) Tuple {
+	if isEven(math.Floor(p.x())) {
+		return patternAt(sp.A, p)
+	}
+	return patternAt(sp.B, p)
+}
+
+// GradientPattern blends linearly between two patterns along the x axis,
+// repeating every unit.
+type GradientPattern struct {
+	pattern
+	A, B Pattern
+}
+
+// NewGradientPattern creates a new gradient pattern from color a at x=0
+// to color b approaching x=1.
+func NewGradientPattern(a, b Tuple) *GradientPattern {
+	return NewNestedGradientPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+// NewNestedGradientPattern creates a new gradient pattern like NewGradientPattern, but
+// between two patterns rather than colors.
+func NewNestedGradientPattern(a, b Pattern) *GradientPattern {
+	return &GradientPattern{newPattern(), a, b}
+}
+
+// PatternAt returns the color of a gradient pattern at a point in pattern space.
+func (gp *GradientPattern) PatternAt(p Tuple) Tuple {
+	a := patternAt(gp.A, p)
+	b := patternAt(gp.B, p)
+
+	fraction := p.x() - math.Floor(p.x())
+	return a.Add(b.Sub(a).Multiply(fraction))
+}
+
+// RingPattern alternates between two patterns in concentric rings around
+// the y axis.
+type RingPattern struct {
+	pattern
+	A, B Pattern
+}
+
+// NewRingPattern creates a new ring pattern alternating between two
+// colors every unit from the y axis, starting with a.
+func NewRingPattern(a, b Tuple) *RingPattern {
+	return NewNestedRingPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+// NewNestedRingPattern creates a new ring pattern like NewRingPattern, but
+// between two patterns rather than colors.
+func NewNestedRingPattern(a, b Pattern) *RingPattern {
+	return &RingPattern{newPattern(), a, b}
+}
+
+// PatternAt returns the color of a ring pattern at a point in pattern space.
+func (rp *RingPattern) PatternAt(p Tuple) Tuple {
+	if isEven(math.Floor(math.Sqrt(p.x()*p.x() + p.z()*p.z()))) {
+		return patternAt(rp.A, p)
+	}
+	return patternAt(rp.B, p)
+}
+
+// CheckerPattern alternates between two patterns in unit cubes.
+type CheckerPattern struct {
+	pattern
+	A, B Pattern
+}
+
+// NewCheckerPattern creates a new 3D checker pattern alternating between
+// two colors, with a in the cube at the origin.
+func NewCheckerPattern(a, b Tuple) *CheckerPattern {
+	return NewNestedCheckerPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+// NewNestedCheckerPattern creates a new checker pattern like NewCheckerPattern, but
+// between two patterns rather than colors.
+func NewNestedCheckerPattern(a, b Pattern) *CheckerPattern {
+	return &CheckerPattern{newPattern(), a, b}
+}
+
+// PatternAt returns the color of a checker pattern at a point in pattern space.
+func (cp *CheckerPattern) PatternAt(p Tuple) Tuple {
+	if isEven(math.Floor(p.x()) + math.Floor(p.y()) + math.Floor(p.z())) {
+		return patternAt(cp.A, p)
+	}
+	return patternAt(cp.B, p)
+}
+
+// BlendPattern averages the colors of two patterns.
+type BlendPattern struct {
+	pattern
+	A, B Pattern
+}
+
+// NewBlendPattern creates a new pattern averaging two patterns.
+func NewBlendPattern(a, b Pattern) *BlendPattern {
+	return &BlendPattern{newPattern(), a, b}
+}
+
+// PatternAt returns the color of a blend pattern at a point in pattern space.
+func (bp *BlendPattern) PatternAt(p Tuple) Tuple {
+	return patternAt(bp.A, p).Add(patternAt(bp.B, p)).Multiply(0.5)
+}
+
+// PerturbedPattern jitters the points at which another pattern is
+// evaluated using Perlin noise, giving it an irregular, natural look.
+type PerturbedPattern struct {
+	pattern
+	Pattern Pattern
+	// Scale is the maximum distance a point is jittered along each axis.
+	Scale float64
+	noise *Perlin
+}
+
+// NewPerturbedPattern creates a new pattern perturbing another pattern by
+// up to scale units, using noise generated from a seed.
+func NewPerturbedPattern(pat Pattern, scale float64, seed int64) *PerturbedPattern {
+	return &PerturbedPattern{newPattern(), pat, scale, NewPerlin(seed)}
+}
+
+// PatternAt returns the color of a perturbed pattern at a point in
+// pattern space.
+func (pp *PerturbedPattern) PatternAt(p Tuple) Tuple {
+	// offset the noise lookups so each axis is jittered independently
+	x, y, z := p.x(), p.y(), p.z()
+	jittered := Point(
+		x+pp.noise.Noise(x, y, z)*pp.Scale,
+		y+pp.noise.Noise(x, y, z+1)*pp.Scale,
+		z+pp.noise.Noise(x, y, z+2)*pp.Scale)
+
+	return patternAt(pp.Pattern, jittered)
+}
+
+// isEven returns true if a whole number is even.
+func isEven(f float64) bool {
+	return math.Mod(f, 2) == 0
+}
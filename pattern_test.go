@@ -0,0 +1,245 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	white = Color(1, 1, 1)
+	black = Color(0, 0, 0)
+)
+
+// testPattern is a pattern returning the point it was evaluated at as
+// a color.
+type testPattern struct {
+	pattern
+}
+
+func newTestPattern() *testPattern {
+	return &testPattern{newPattern()}
+}
+
+func (tp *testPattern) PatternAt(p Tuple) Tuple {
+	return Color(p.x(), p.y(), p.z())
+}
+
+func TestPatternTransform(t *testing.T) {
+	type test struct {
+		object   Matrix
+		pattern  Matrix
+		point    Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		{ScalingMatrix(2, 2, 2), IdentityMatrix(4), Point(2, 3, 4), Color(1, 1.5, 2)},
+		{IdentityMatrix(4), ScalingMatrix(2, 2, 2), Point(2, 3, 4), Color(1, 1.5, 2)},
+		{ScalingMatrix(2, 2, 2), TranslationMatrix(0.5, 1, 1.5), Point(2.5, 3, 3.5), Color(0.75, 0.5, 0.25)},
+	}
+
+	for i, td := range tds {
+		s := NewSphere()
+		if err := s.SetTransform(td.object); err != nil {
+			t.Error(err)
+			return
+		}
+		p := newTestPattern()
+		if err := p.SetTransform(td.pattern); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output := PatternAtShape(p, s, td.point)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	// patterns are evaluated in the object space of shapes within groups
+	g := NewGroup()
+	g.SetTransform(ScalingMatrix(2, 2, 2))
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(1, 0, 0))
+	g.AddChild(s)
+
+	output := PatternAtShape(newTestPattern(), s, Point(4, 2, 2))
+	if !output.Equal(Color(1, 1, 1), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(1, 1, 1), output)
+	}
+}
+
+func TestPatternAt(t *testing.T) {
+	type test struct {
+		pattern  Pattern
+		point    Tuple
+		expected Tuple
+	}
+
+	stripe := NewStripePattern(white, black)
+	gradient := NewGradientPattern(white, black)
+	ring := NewRingPattern(white, black)
+	checker := NewCheckerPattern(white, black)
+
+	tds := []test{
+		// stripes are constant in y and z, and alternate in x
+		{stripe, Point(0, 1, 0), white},
+		{stripe, Point(0, 2, 0), white},
+		{stripe, Point(0, 0, 1), white},
+		{stripe, Point(0, 0, 2), white},
+		{stripe, Point(0.9, 0, 0), white},
+		{stripe, Point(1, 0, 0), black},
+		{stripe, Point(-0.1, 0, 0), black},
+		{stripe, Point(-1, 0, 0), black},
+		{stripe, Point(-1.1, 0, 0), white},
+		// gradients interpolate between colors
+		{gradient, Point(0, 0, 0), white},
+		{gradient, Point(0.25, 0, 0), Color(0.75, 0.75, 0.75)},
+		{gradient, Point(0.5, 0, 0), Color(0.5, 0.5, 0.5)},
+		{gradient, Point(0.75, 0, 0), Color(0.25, 0.25, 0.25)},
+		// rings extend in x and z
+		{ring, Point(0, 0, 0), white},
+		{ring, Point(1, 0, 0), black},
+		{ring, Point(0, 0, 1), black},
+		{ring, Point(0.708, 0, 0.708), black},
+		// checkers repeat in x, y and z
+		{checker, Point(0, 0, 0), white},
+		{checker, Point(0.99, 0, 0), white},
+		{checker, Point(1.01, 0, 0), black},
+		{checker, Point(0, 0.99, 0), white},
+		{checker, Point(0, 1.01, 0), black},
+		{checker, Point(0, 0, 0.99), white},
+		{checker, Point(0, 0, 1.01), black},
+		{checker, Point(-0.5, 0, 0), black},
+	}
+
+	for i, td := range tds {
+		output := td.pattern.PatternAt(td.point)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestLightingPattern(t *testing.T) {
+	m := DefaultMaterial()
+	m.Pattern = NewStripePattern(white, black)
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+
+	l := PointLight{Point(0, 0, -10), Color(1, 1, 1)}
+	eyev := Vector(0, 0, -1)
+	normalv := Vector(0, 0, -1)
+
+	c1 := Lighting(m, NewSphere(), l, Point(0.9, 0, 0), eyev, normalv, false)
+	c2 := Lighting(m, NewSphere(), l, Point(1.1, 0, 0), eyev, normalv, false)
+	if !c1.Equal(white, epsilon) {
+		t.Errorf("expected %v, returned %v", white, c1)
+	}
+	if !c2.Equal(black, epsilon) {
+		t.Errorf("expected %v, returned %v", black, c2)
+	}
+}
+
+func TestNestedPattern(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected Tuple
+	}
+
+	// checkers of stripes running in x and stripes running in z
+	red := Color(1, 0, 0)
+	green := Color(0, 1, 0)
+	s1 := NewStripePattern(white, black)
+	s1.SetTransform(ScalingMatrix(0.5, 0.5, 0.5))
+	s2 := NewStripePattern(red, green)
+	s2.SetTransform(RotationYMatrix(math.Pi / 2.).Multiply(ScalingMatrix(0.5, 0.5, 0.5)))
+	checker := NewNestedCheckerPattern(s1, s2)
+
+	tds := []test{
+		// inside the first checker, stripes alternate every half unit in x
+		{Point(0.25, 0, 0.25), white},
+		{Point(0.75, 0, 0.25), black},
+		// inside the second checker, stripes alternate every half unit in z
+		{Point(1.25, 0, 0.25), green},
+		{Point(1.25, 0, 0.75), red},
+	}
+
+	for i, td := range tds {
+		output := checker.PatternAt(td.point)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestBlendPattern(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected Tuple
+	}
+
+	red := Color(1, 0, 0)
+	s1 := NewStripePattern(white, black)
+	s2 := NewStripePattern(red, black)
+	s2.SetTransform(RotationYMatrix(math.Pi / 2.))
+	blend := NewBlendPattern(s1, s2)
+
+	tds := []test{
+		{Point(0.5, 0, 0.5), Color(0.5, 0.5, 0.5)},
+		{Point(1.5, 0, 0.5), black},
+		{Point(0.5, 0, 1.5), Color(1, 0.5, 0.5)},
+		{Point(1.5, 0, 1.5), Color(0.5, 0, 0)},
+	}
+
+	for i, td := range tds {
+		output := blend.PatternAt(td.point)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestPerturbedPattern(t *testing.T) {
+	p := newTestPattern()
+
+	// no perturbation leaves the point unchanged
+	output := NewPerturbedPattern(p, 0, 1).PatternAt(Point(0.3, 0.4, 0.5))
+	if !output.Equal(Color(0.3, 0.4, 0.5), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.3, 0.4, 0.5), output)
+	}
+
+	// points are jittered by at most scale along each axis
+	pp := NewPerturbedPattern(p, 0.2, 1)
+	moved := false
+	for x := 0.; x < 3; x += 0.37 {
+		point := Point(x, x/2, x/3)
+		output := pp.PatternAt(point)
+		for i := 0; i < 3; i++ {
+			if math.Abs(output[i]-point[i]) > 0.2*1.1 {
+				t.Errorf("expected jitter within %f, returned %v for %v", 0.2, output, point)
+			}
+		}
+		moved = moved || !output.Equal(Color(point.x(), point.y(), point.z()), epsilon)
+	}
+	if !moved {
+		t.Error("expected points to be jittered, returned unchanged points")
+	}
+
+	// the same seed produces the same pattern
+	pp1 := NewPerturbedPattern(p, 0.2, 1)
+	pp2 := NewPerturbedPattern(p, 0.2, 2)
+	same, different := true, false
+	for x := 0.; x < 3; x += 0.37 {
+		point := Point(x, x/2, x/3)
+		same = same && pp.PatternAt(point).Equal(pp1.PatternAt(point), epsilon)
+		different = different || !pp.PatternAt(point).Equal(pp2.PatternAt(point), epsilon)
+	}
+	if !same {
+		t.Error("expected equal seeds to produce equal patterns")
+	}
+	if !different {
+		t.Error("expected different seeds to produce different patterns")
+	}
+}
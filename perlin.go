@@ -0,0 +1,90 @@
+package tracer
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Perlin generates 3D gradient noise using Ken Perlin's improved noise
+// function, with a permutation table shuffled from a seed so that the
+// same seed always produces the same noise.
+type Perlin struct {
+	perm [512]int
+}
+
+// NewPerlin creates a new noise generator from a seed.
+func NewPerlin(seed int64) *Perlin {
+	p := &Perlin{}
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		// repeat the table to avoid wrapping indices
+		p.perm[i] = v
+		p.perm[i+256] = v
+	}
+
+	return p
+}
+
+// Noise returns the noise value at a point, roughly between -1 and 1.
+// Noise is zero at every point with whole number coordinates.
+func (p *Perlin) Noise(x, y, z float64) float64 {
+	// find the unit cube containing the point
+	xf, yf, zf := math.Floor(x), math.Floor(y), math.Floor(z)
+	X, Y, Z := int(xf)&255, int(yf)&255, int(zf)&255
+
+	// find the relative position of the point in the cube
+	x, y, z = x-xf, y-yf, z-zf
+	u, v, w := fade(x), fade(y), fade(z)
+
+	// hash the coordinates of the eight cube corners
+	A := p.perm[X] + Y
+	AA := p.perm[A] + Z
+	AB := p.perm[A+1] + Z
+	B := p.perm[X+1] + Y
+	BA := p.perm[B] + Z
+	BB := p.perm[B+1] + Z
+
+	// blend the gradients from each corner
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(p.perm[AA], x, y, z), grad(p.perm[BA], x-1, y, z)),
+			lerp(u, grad(p.perm[AB], x, y-1, z), grad(p.perm[BB], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, grad(p.perm[AA+1], x, y, z-1), grad(p.perm[BA+1], x-1, y, z-1)),
+			lerp(u, grad(p.perm[AB+1], x, y-1, z-1), grad(p.perm[BB+1], x-1, y-1, z-1))))
+}
+
+// fade eases a coordinate toward the cube edges with 6t^5 - 15t^4 + 10t^3.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp linearly interpolates between a and b.
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad returns the dot product of a distance vector with one of twelve
+// gradient directions selected by a hash.
+func grad(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
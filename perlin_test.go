@@ -0,0 +1,41 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPerlinNoise(t *testing.T) {
+	p := NewPerlin(42)
+
+	// noise is zero on the integer lattice
+	for i, pt := range []Tuple{Point(0, 0, 0), Point(1, 2, 3), Point(-4, 7, -1), Point(300, -300, 5)} {
+		output := p.Noise(pt.x(), pt.y(), pt.z())
+		if !eq(output, 0, epsilon) {
+			t.Errorf("test %d failed: expected 0, returned %f", i, output)
+		}
+	}
+
+	// noise is bounded, continuous and deterministic for a seed
+	p1 := NewPerlin(42)
+	nonzero := false
+	for x := -2.; x < 2; x += 0.13 {
+		for y := -2.; y < 2; y += 0.17 {
+			z := x * y
+			output := p.Noise(x, y, z)
+			if math.Abs(output) > 1.1 {
+				t.Errorf("expected noise within [-1, 1], returned %f", output)
+			}
+			if d := math.Abs(output - p.Noise(x+0.001, y, z)); d > 0.01 {
+				t.Errorf("expected continuous noise, returned jump of %f", d)
+			}
+			if output != p1.Noise(x, y, z) {
+				t.Errorf("expected equal noise for equal seeds, returned %f %f", output, p1.Noise(x, y, z))
+			}
+			nonzero = nonzero || output != 0
+		}
+	}
+	if !nonzero {
+		t.Error("expected nonzero noise, returned zero everywhere")
+	}
+}
@@ -0,0 +1,107 @@
+package tracer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// PFMFormat is the color Portable Float Map format of a canvas.
+const PFMFormat = "PF"
+
+// PFMGrayscaleFormat is the grayscale Portable Float Map format.
+const PFMGrayscaleFormat = "Pf"
+
+// WritePFM writes a canvas to w as a little-endian color Portable Float
+// Map, keeping color values as 32-bit floats.
+func (c Canvas) WritePFM(w io.Writer) error {
+	return writeBuffered(w, func(b *bufio.Writer) {
+		// a negative scale marks little-endian data
+		fmt.Fprintf(b, "%s\n%d %d\n-1.0\n", PFMFormat, c.Width(), c.Height())
+
+		// rows are stored from bottom to top
+		buf := make([]byte, 4)
+		for y := len(c) - 1; y >= 0; y-- {
+			for _, t := range c[y] {
+				for _, v := range t[:3] {
+					binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(v)))
+					b.Write(buf)
+				}
+			}
+		}
+	})
+}
+
+// ParsePFM reads a color or grayscale Portable Float Map into a canvas.
+// The magnitude of the scale in the header is ignored.
+func ParsePFM(r io.Reader) (Canvas, error) {
+	p := ppmReader{bufio.NewReader(r)}
+
+	format, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("PFM header: %w", err)
+	}
+	channels := 3
+	switch format {
+	case PFMFormat:
+	case PFMGrayscaleFormat:
+		channels = 1
+	default:
+		return nil, fmt.Errorf("PFM header: unsupported format %q", format)
+	}
+
+	width, err := p.int()
+	if err != nil {
+		return nil, fmt.Errorf("PFM header: width: %w", err)
+	}
+	height, err := p.int()
+	if err != nil {
+		return nil, fmt.Errorf("PFM header: height: %w", err)
+	}
+
+	s, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("PFM header: scale: %w", err)
+	}
+	scale, err := strconv.ParseFloat(s, 64)
+	if err != nil || scale == 0 {
+		return nil, fmt.Errorf("PFM header: invalid scale %q", s)
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		order = binary.LittleEndian
+	}
+
+	// a single whitespace character separates the header from the data
+	if b, err := p.r.ReadByte(); err != nil || !isPPMSpace(b) {
+		return nil, errors.New("PFM header: expected whitespace after scale")
+	}
+
+	// rows are stored from bottom to top
+	c, err := readImage("PFM", width, height, func(x, y int) (Tuple, error) {
+		var buf [12]byte
+		if _, err := io.ReadFull(p.r, buf[:channels*4]); err != nil {
+			return nil, fmt.Errorf("PFM data: truncated at row %d", height-1-y)
+		}
+
+		color := Color(0, 0, 0)
+		for k := range color {
+			i := k % channels * 4
+			color[k] = float64(math.Float32frombits(order.Uint32(buf[i:])))
+		}
+		return color, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(c)-1; i < j; i, j = i+1, j-1 {
+		c[i], c[j] = c[j], c[i]
+	}
+
+	return c, nil
+}
@@ -0,0 +1,104 @@
+package tracer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePFM(t *testing.T) {
+	c := NewCanvas(1, 2)
+	c.WritePixel(0, 0, Color(1, -2, 0))
+	c.WritePixel(0, 1, Color(0.5, 0, 4))
+
+	var b bytes.Buffer
+	if err := c.WritePFM(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the bottom row comes first
+	expected := "PF\n1 2\n-1.0\n" +
+		"\x00\x00\x00\x3f\x00\x00\x00\x00\x00\x00\x80\x40" +
+		"\x00\x00\x80\x3f\x00\x00\x00\xc0\x00\x00\x00\x00"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+}
+
+func TestParsePFM(t *testing.T) {
+	type test struct {
+		input    string
+		expected Canvas
+	}
+
+	tds := []test{
+		// big-endian
+		{"PF\n2 1\n1.0\n\x3f\x80\x00\x00\x40\x00\x00\x00\x00\x00\x00\x00" +
+			"\x3f\x00\x00\x00\x00\x00\x00\x00\x41\x20\x00\x00",
+			Canvas{{Color(1, 2, 0), Color(0.5, 0, 10)}}},
+		// little-endian grayscale
+		{"Pf\n1 2\n-2.5\n\x00\x00\x00\x3f\x00\x00\x80\x3f",
+			Canvas{{Color(1, 1, 1)}, {Color(0.5, 0.5, 0.5)}}},
+	}
+
+	for i, td := range tds {
+		output, err := ParsePFM(strings.NewReader(td.input))
+		if err != nil {
+			t.Errorf("test %d failed: %v", i, err)
+			continue
+		}
+		if !canvasEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestParsePFMRoundTrip(t *testing.T) {
+	c := NewCanvas(10, 4)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(float64(x)/3, float64(y)*10, -0.5))
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.WritePFM(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	output, err := ParsePFM(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// values are stored as 32-bit floats
+	if !canvasEqual(output, c, Epsilon) {
+		t.Error("expected round trip to preserve canvas")
+	}
+}
+
+func TestParsePFMErrors(t *testing.T) {
+	tds := []string{
+		"",
+		"P6\n1 1\n255\n\x00\x00\x00",
+		"PF\n1\n",
+		"PF\n0 1\n-1.0\n",
+		"PF\n1 1\nx\n",
+		"PF\n1 1\n0\n\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
+		"PF\n1 1\n-1.0\n\x00\x00\x00\x00",
+		"Pf\n2 1\n-1.0\n\x00\x00\x00\x00",
+		// too large to allocate
+		"PF 1099511627776 1 -1.0\n",
+		"PF 1099511627776 1099511627776 -1.0\n",
+		// large but truncated
+		"PF 8192 8192 -1.0\n\x00\x00\x00\x00",
+	}
+
+	for i, td := range tds {
+		if _, err := ParsePFM(strings.NewReader(td)); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
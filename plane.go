@@ -0,0 +1,37 @@
+package tracer
+
+import "math"
+
+// Plane is an infinite plane spanning the xz axes of its object space.
+type Plane struct {
+	shape
+}
+
+// NewPlane creates a new plane with an identity transform and the
+// default material.
+func NewPlane() *Plane {
+	return &Plane{newShape()}
+}
+
+// LocalIntersect returns the intersection of an object space ray with
+// a plane. A ray parallel to the plane never intersects it.
+func (p *Plane) LocalIntersect(r Ray) Intersections {
+	if math.Abs(r.Direction.y()) < Epsilon {
+		return nil
+	}
+
+	return Intersections{{T: -r.Origin.y() / r.Direction.y(), Object: p}}
+}
+
+// LocalNormalAt returns the normal of a plane, which is the same at
+// every point.
+func (p *Plane) LocalNormalAt(_ Tuple, _ Intersection) Tuple {
+	return Vector(0, 1, 0)
+}
+
+// Bounds returns the bounds of a plane in object space, which are
+// infinite along the x and z axes.
+func (p *Plane) Bounds() Bounds {
+	inf := math.Inf(1)
+	return Bounds{Point(-inf, 0, -inf), Point(inf, 0, inf)}
+}
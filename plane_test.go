@@ -0,0 +1,40 @@
+package tracer
+
+import "testing"
+
+func TestPlaneNormalAt(t *testing.T) {
+	p := NewPlane()
+
+	for i, point := range []Tuple{Point(0, 0, 0), Point(10, 0, -10), Point(-5, 0, 150)} {
+		output := p.LocalNormalAt(point, Intersection{})
+		if !output.Equal(Vector(0, 1, 0), epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, Vector(0, 1, 0), output)
+		}
+	}
+}
+
+func TestPlaneIntersect(t *testing.T) {
+	type test struct {
+		ray      Ray
+		expected []float64
+	}
+
+	tds := []test{
+		// parallel to the plane
+		{Ray{Point(0, 10, 0), Vector(0, 0, 1)}, nil},
+		// coplanar with the plane
+		{Ray{Point(0, 0, 0), Vector(0, 0, 1)}, nil},
+		// from above
+		{Ray{Point(0, 1, 0), Vector(0, -1, 0)}, []float64{1}},
+		// from below
+		{Ray{Point(0, -1, 0), Vector(0, 1, 0)}, []float64{1}},
+	}
+
+	p := NewPlane()
+	for i, td := range tds {
+		output := p.LocalIntersect(td.ray)
+		if !intersectionsEqual(output, td.expected, p, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
@@ -38,3 +38,8 @@ func (t Tuple) Cross(t1 Tuple) Tuple {
 		t.z()*t1.x()-t.x()*t1.z(),
 		t.x()*t1.y()-t.y()*t1.x())
 }
+
+// Reflect reflects a vector around a normal vector.
+func (t Tuple) Reflect(normal Tuple) Tuple {
+	return t.Sub(normal.Multiply(2 * t.Dot(normal)))
+}
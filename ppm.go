@@ -0,0 +1,331 @@
+package tracer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PPMBinaryFormat is the binary PPM format of a canvas.
+const PPMBinaryFormat = "P6"
+
+// MaxImagePixels is the largest number of pixels in an image read by
+// ParsePPM, ParsePFM or ParseHDR.
+const MaxImagePixels = 1 << 26
+
+// PPMOptions configures how a canvas is written as a PPM image.
+type PPMOptions struct {
+	// Format is PPMFormat for plain text or PPMBinaryFormat for binary
+	// output, defaulting to PPMFormat.
+	Format string
+	// MaxColorValue is the value of a fully saturated color channel,
+	// between 1 and 65535, defaulting to PPMMaxColorValue. Binary images
+	// with a MaxColorValue above 255 use two bytes per channel.
+	MaxColorValue int
+	// Encoding controls tone mapping, the transfer function and dithering.
+	Encoding
+}
+
+// WritePPM writes a canvas to w as a PPM image, without building the
+// whole image in memory.
+func (c Canvas) WritePPM(w io.Writer, opt PPMOptions) error {
+	if opt.Format == "" {
+		opt.Format = PPMFormat
+	}
+	if opt.MaxColorValue == 0 {
+		opt.MaxColorValue = PPMMaxColorValue
+	}
+	if opt.Format != PPMFormat && opt.Format != PPMBinaryFormat {
+		return fmt.Errorf("unsupported PPM format %q", opt.Format)
+	}
+	if opt.MaxColorValue < 1 || opt.MaxColorValue > 65535 {
+		return errors.New("PPM max color value must be between 1 and 65535")
+	}
+
+	return writeBuffered(w, func(b *bufio.Writer) {
+		fmt.Fprintf(b, "%s\n%d %d\n%d\n", opt.Format, c.Width(), c.Height(), opt.MaxColorValue)
+
+		if opt.Format == PPMBinaryFormat {
+			c.writePPMBinary(b, opt.Encoding, opt.MaxColorValue)
+		} else {
+			c.writePPMPlain(b, opt.Encoding, opt.MaxColorValue)
+		}
+	})
+}
+
+// writeBuffered calls write with a buffered writer wrapping w, returning
+// the first error writing to w. Since bufio.Writer keeps the first write
+// error, write does not need to check for errors itself.
+func writeBuffered(w io.Writer, write func(b *bufio.Writer)) error {
+	b := bufio.NewWriter(w)
+	write(b)
+	return b.Flush()
+}
+
+// writePPMPlain writes the pixels of a canvas as decimal color values,
+// wrapping lines before they exceed PPMMaxCharacterCount.
+func (c Canvas) writePPMPlain(b *bufio.Writer, e Encoding, max int) {
+	// wrap once there is no room left for a value and a space
+	wrap := PPMMaxCharacterCount - len(strconv.Itoa(max))
+
+	count := 0
+	q := e.newQuantizer(c.Width(), max)
+	values := make([]int, 3*c.Width())
+	for i, row := range c {
+		q.row(row, values)
+
+		// add color values to string
+		for k, v := range values {
+			s := strconv.Itoa(v)
+			l := count + len(s)
+
+			if l > PPMMaxCharacterCount {
+				fmt.Fprintf(b, "\n%s", s)
+				count = len(s)
+
+			} else if l == PPMMaxCharacterCount {
+				fmt.Fprintf(b, "%s\n", s)
+				count = 0
+
+			} else {
+				b.WriteString(s)
+				count += len(s)
+			}
+
+			// add new line or space if necessary
+			if count >= wrap {
+				b.WriteByte('\n')
+				count = 0
+
+			} else if count != 0 && k != len(values)-1 {
+				b.WriteByte(' ')
+				count += 1
+			}
+		}
+
+		// start new row
+		if i != len(c)-1 {
+			b.WriteByte('\n')
+			count = 0
+		}
+	}
+}
+
+// writePPMBinary writes the pixels of a canvas as one byte per color
+// value, or two big-endian bytes if max exceeds 255.
+func (c Canvas) writePPMBinary(b *bufio.Writer, e Encoding, max int) {
+	q := e.newQuantizer(c.Width(), max)
+	values := make([]int, 3*c.Width())
+	for _, row := range c {
+		q.row(row, values)
+		for _, v := range values {
+			if max > 255 {
+				b.WriteByte(byte(v >> 8))
+			}
+			b.WriteByte(byte(v))
+		}
+	}
+}
+
+// quantize scales a color value between 0 and 1 to an integer between
+// 0 and max, clamping values out of range.
+func quantize(v float64, max int) int {
+	v *= float64(max)
+	if v < 0 {
+		v = 0
+	}
+	if v > float64(max) {
+		v = float64(max)
+	}
+
+	return round(v)
+}
+
+// ParsePPM reads a plain (P3) or binary (P6) PPM image into a canvas,
+// scaling color values by the max color value of the image.
+func ParsePPM(r io.Reader) (Canvas, error) {
+	p := ppmReader{bufio.NewReader(r)}
+
+	format, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("PPM header: %w", err)
+	}
+	if format != PPMFormat && format != PPMBinaryFormat {
+		return nil, fmt.Errorf("PPM header: unsupported format %q", format)
+	}
+
+	header := make([]int, 3)
+	for i, name := range []string{"width", "height", "max color value"} {
+		header[i], err = p.int()
+		if err != nil {
+			return nil, fmt.Errorf("PPM header: %s: %w", name, err)
+		}
+	}
+	width, height, max := header[0], header[1], header[2]
+	if max < 1 || max > 65535 {
+		return nil, fmt.Errorf("PPM header: max color value %d must be between 1 and 65535", max)
+	}
+
+	read := p.readPlain
+	if format == PPMBinaryFormat {
+		// a single whitespace character, or a comment ending with a
+		// newline, separates the header from the data
+		b, err := p.r.ReadByte()
+		if err == nil && b == '#' {
+			err = p.skipComment()
+		} else if err == nil && !isPPMSpace(b) {
+			err = errors.New("not whitespace")
+		}
+		if err != nil {
+			return nil, errors.New("PPM header: expected whitespace after max color value")
+		}
+		read = p.readBinary
+	}
+
+	return readImage("PPM", width, height, func(x, y int) (Tuple, error) {
+		return read(x, y, max)
+	})
+}
+
+// ppmReader reads the whitespace separated tokens of a PPM image,
+// skipping comments.
+type ppmReader struct {
+	r *bufio.Reader
+}
+
+// token returns the next token, leaving the whitespace following it unread.
+func (p ppmReader) token() (string, error) {
+	var out []byte
+	for {
+		b, err := p.r.ReadByte()
+		if err == io.EOF && len(out) > 0 {
+			return string(out), nil
+		}
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case (isPPMSpace(b) || b == '#') && len(out) > 0:
+			// a comment may directly follow a token
+			return string(out), p.r.UnreadByte()
+		case isPPMSpace(b):
+			continue
+		case b == '#':
+			if err := p.skipComment(); err != nil {
+				return "", err
+			}
+		default:
+			out = append(out, b)
+		}
+	}
+}
+
+// skipComment skips the rest of a comment, which runs to the end of the
+// line.
+func (p ppmReader) skipComment() error {
+	if _, err := p.r.ReadString('\n'); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// int returns the next token as a non-negative integer.
+func (p ppmReader) int() (int, error) {
+	s, err := p.token()
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return i, nil
+}
+
+// readPlain reads the decimal color values of the pixel at x, y.
+func (p ppmReader) readPlain(x, y, max int) (Tuple, error) {
+	color := Color(0, 0, 0)
+	for k := range color {
+		v, err := p.int()
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("PPM data: truncated at pixel %d, %d", x, y)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("PPM data: pixel %d, %d: %w", x, y, err)
+		}
+		if v > max {
+			return nil, fmt.Errorf("PPM data: pixel %d, %d: value %d exceeds max color value %d", x, y, v, max)
+		}
+		color[k] = float64(v) / float64(max)
+	}
+
+	return color, nil
+}
+
+// readBinary reads the one or two byte color values of the pixel at x, y.
+func (p ppmReader) readBinary(x, y, max int) (Tuple, error) {
+	size := 1
+	if max > 255 {
+		size = 2
+	}
+
+	var buf [6]byte
+	if _, err := io.ReadFull(p.r, buf[:3*size]); err != nil {
+		return nil, fmt.Errorf("PPM data: truncated at row %d", y)
+	}
+
+	color := Color(0, 0, 0)
+	for k := range color {
+		v := int(buf[k*size])
+		if size == 2 {
+			v = v<<8 | int(buf[k*size+1])
+		}
+		if v > max {
+			return nil, fmt.Errorf("PPM data: pixel %d, %d: value %d exceeds max color value %d", x, y, v, max)
+		}
+		color[k] = float64(v) / float64(max)
+	}
+
+	return color, nil
+}
+
+// readImage reads a width by height image by calling pixel for each
+// pixel, from left to right and top to bottom. The canvas grows as pixels
+// are read rather than being allocated from the header, so that a
+// truncated image claiming a huge size fails before using much memory.
+// Sizes that are not positive or exceed MaxImagePixels are rejected.
+func readImage(format string, width, height int, pixel func(x, y int) (Tuple, error)) (Canvas, error) {
+	if width < 1 || height < 1 {
+		return nil, fmt.Errorf("%s header: invalid size %dx%d", format, width, height)
+	}
+	if width > MaxImagePixels/height {
+		return nil, fmt.Errorf("%s header: size %dx%d exceeds %d pixels", format, width, height, MaxImagePixels)
+	}
+
+	var c Canvas
+	for y := 0; y < height; y++ {
+		var row []Tuple
+		for x := 0; x < width; x++ {
+			t, err := pixel(x, y)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, t)
+		}
+		c = append(c, row)
+	}
+
+	return c, nil
+}
+
+// isPPMSpace returns true if a byte is whitespace in a PPM image.
+func isPPMSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
@@ -0,0 +1,234 @@
+package tracer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePPM(t *testing.T) {
+	c := NewCanvas(5, 3)
+	c.WritePixel(0, 0, Color(1.5, 0, 0))
+	c.WritePixel(2, 1, Color(0, 0.5, 0))
+	c.WritePixel(4, 2, Color(-0.5, 0, 1))
+
+	// plain output matches ToPPM
+	var b bytes.Buffer
+	if err := c.WritePPM(&b, PPMOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	if b.String() != canvasPPM1 {
+		t.Errorf("expected %s, returned %s", canvasPPM1, b.String())
+	}
+
+	// binary output
+	b.Reset()
+	if err := c.WritePPM(&b, PPMOptions{Format: PPMBinaryFormat}); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "P6\n5 3\n255\n" +
+		"\xff\x00\x00" + strings.Repeat("\x00", 3*4) +
+		strings.Repeat("\x00", 3*2) + "\x00\x80\x00" + strings.Repeat("\x00", 3*2) +
+		strings.Repeat("\x00", 3*4) + "\x00\x00\xff"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+}
+
+func TestWritePPM16Bit(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.WritePixel(0, 0, Color(1, 0.5, 0))
+	c.WritePixel(1, 0, Color(0.25, 2, -1))
+
+	var b bytes.Buffer
+	if err := c.WritePPM(&b, PPMOptions{Format: PPMBinaryFormat, MaxColorValue: 65535}); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "P6\n2 1\n65535\n" +
+		"\xff\xff\x80\x00\x00\x00" +
+		"\x40\x00\xff\xff\x00\x00"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+
+	b.Reset()
+	if err := c.WritePPM(&b, PPMOptions{MaxColorValue: 65535}); err != nil {
+		t.Error(err)
+		return
+	}
+	expected = "P3\n2 1\n65535\n65535 32768 0 16384 65535 0"
+	if b.String() != expected {
+		t.Errorf("expected %q, returned %q", expected, b.String())
+	}
+}
+
+func TestWritePPMLineLength(t *testing.T) {
+	c := NewCanvas(20, 2)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(1, 0.8, 0.6))
+		}
+	}
+
+	for _, max := range []int{1, 9, 255, 1000, 65535} {
+		var b bytes.Buffer
+		if err := c.WritePPM(&b, PPMOptions{MaxColorValue: max}); err != nil {
+			t.Error(err)
+			return
+		}
+
+		values := 0
+		for i, line := range strings.Split(b.String(), "\n") {
+			if len(line) > PPMMaxCharacterCount {
+				t.Errorf("max %d: line %d has %d characters", max, i, len(line))
+			}
+			if i >= 3 {
+				values += len(strings.Fields(line))
+			}
+		}
+		if values != 20*2*3 {
+			t.Errorf("max %d: expected %d values, returned %d", max, 20*2*3, values)
+		}
+	}
+}
+
+func TestWritePPMErrors(t *testing.T) {
+	tds := []PPMOptions{
+		{Format: "P5"},
+		{MaxColorValue: -1},
+		{MaxColorValue: 65536},
+	}
+
+	c := NewCanvas(2, 2)
+	for i, td := range tds {
+		if err := c.WritePPM(&bytes.Buffer{}, td); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
+
+func TestParsePPM(t *testing.T) {
+	type test struct {
+		input    string
+		expected Canvas
+	}
+
+	tds := []test{
+		{"P3\n1 2\n255\n255 0 0\n0 128 255\n",
+			Canvas{{Color(1, 0, 0)}, {Color(0, 128./255, 1)}}},
+		// comments and arbitrary whitespace
+		{"P3\n# created by hand\n2  1 # size\n\t100\n100 50 0 # first\r\n\n0   25 100",
+			Canvas{{Color(1, 0.5, 0), Color(0, 0.25, 1)}}},
+		// binary
+		{"P6 2 1 255\n\xff\x80\x00\x00\x40\xff",
+			Canvas{{Color(1, 128./255, 0), Color(0, 64./255, 1)}}},
+		// comments directly after values
+		{"P3\n2# width\n1 255# max\n255 0 0# first\n0 51 255",
+			Canvas{{Color(1, 0, 0), Color(0, 0.2, 1)}}},
+		{"P6 1 1 255# max\n\x00\x33\xff",
+			Canvas{{Color(0, 0.2, 1)}}},
+		// binary with a comment and two byte values
+		{"P6\n#comment\n1 1\n1000\r\x03\xe8\x01\xf4\x00\x00",
+			Canvas{{Color(1, 0.5, 0)}}},
+	}
+
+	for i, td := range tds {
+		output, err := ParsePPM(strings.NewReader(td.input))
+		if err != nil {
+			t.Errorf("test %d failed: %v", i, err)
+			continue
+		}
+		if !canvasEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestParsePPMRoundTrip(t *testing.T) {
+	c := NewCanvas(30, 7)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(float64(x)/29, float64(y)/6, 0.5))
+		}
+	}
+
+	for i, opt := range []PPMOptions{
+		{},
+		{Format: PPMBinaryFormat},
+		{MaxColorValue: 65535},
+		{Format: PPMBinaryFormat, MaxColorValue: 65535},
+	} {
+		var b bytes.Buffer
+		if err := c.WritePPM(&b, opt); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output, err := ParsePPM(&b)
+		if err != nil {
+			t.Errorf("test %d failed: %v", i, err)
+			continue
+		}
+
+		// values are only as precise as the max color value
+		max := opt.MaxColorValue
+		if max == 0 {
+			max = PPMMaxColorValue
+		}
+		if !canvasEqual(output, c, 0.5/float64(max)+epsilon) {
+			t.Errorf("test %d failed: expected round trip to preserve canvas", i)
+		}
+	}
+}
+
+func TestParsePPMErrors(t *testing.T) {
+	tds := []string{
+		"",
+		"P5\n1 1\n255\n\x00",
+		"P3\n1\n",
+		"P3\nx 1\n255\n",
+		"P3\n0 1\n255\n",
+		"P3\n1 1\n0\n0 0 0",
+		"P3\n1 1\n70000\n0 0 0",
+		"P3\n2 1\n255\n0 0 0 0 0",
+		"P3\n1 1\n255\n0 0 256",
+		"P3\n1 1\n255\n0 0 a",
+		"P6\n2 1\n255\n\x00\x00\x00\x00",
+		"P6\n1 1\n255",
+		"P6\n1 1\n1000\n\xff\xff\x00\x00\x00\x00",
+		// too large to allocate
+		"P6 1099511627776 1099511627776 255\n",
+		"P6 9223372036854775807 2 255\n",
+		// large but truncated
+		"P6 8192 8192 255\n\x00\x00\x00",
+		"P3 8192 8192 255\n0 0 0",
+	}
+
+	for i, td := range tds {
+		if _, err := ParsePPM(strings.NewReader(td)); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
+
+// canvasEqual returns true if two canvases are the same size and each
+// pixel is within some epsilon of its counterpart.
+func canvasEqual(c, c1 Canvas, e float64) bool {
+	if len(c) != len(c1) {
+		return false
+	}
+	for y := range c {
+		if len(c[y]) != len(c1[y]) {
+			return false
+		}
+		for x := range c[y] {
+			if !c[y][x].Equal(c1[y][x], e) {
+				return false
+			}
+		}
+	}
+	return true
+}
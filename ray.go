@@ -0,0 +1,35 @@
+package tracer
+
+import "errors"
+
+// Ray represents a line with a starting point and a direction.
+type Ray struct {
+	Origin    Tuple
+	Direction Tuple
+}
+
+// NewRay creates a new ray, failing if the origin is not a point or
+// the direction is not a vector.
+func NewRay(origin, direction Tuple) (Ray, error) {
+	if len(origin) != 4 || origin.w() != 1 {
+		return Ray{}, errors.New("ray origin must be a point")
+	}
+	if len(direction) != 4 || direction.w() != 0 {
+		return Ray{}, errors.New("ray direction must be a vector")
+	}
+
+	return Ray{Origin: origin, Direction: direction}, nil
+}
+
+// Position computes the point at distance t along a ray.
+func (r Ray) Position(t float64) Tuple {
+	return r.Origin.Add(r.Direction.Multiply(t))
+}
+
+// Transform applies a transformation matrix to a ray, returning a new ray.
+func (r Ray) Transform(m Matrix) Ray {
+	return Ray{
+		Origin:    m.MultiplyT(r.Origin),
+		Direction: m.MultiplyT(r.Direction),
+	}
+}
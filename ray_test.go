@@ -0,0 +1,87 @@
+package tracer
+
+import "testing"
+
+func TestNewRay(t *testing.T) {
+	r, err := NewRay(Point(1, 2, 3), Vector(4, 5, 6))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !r.Origin.Equal(Point(1, 2, 3), epsilon) {
+		t.Errorf("expected %v, returned %v", Point(1, 2, 3), r.Origin)
+	}
+	if !r.Direction.Equal(Vector(4, 5, 6), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(4, 5, 6), r.Direction)
+	}
+
+	type test struct {
+		origin    Tuple
+		direction Tuple
+	}
+
+	tds := []test{
+		{Vector(1, 2, 3), Vector(4, 5, 6)},
+		{Point(1, 2, 3), Point(4, 5, 6)},
+		{Color(1, 2, 3), Vector(4, 5, 6)},
+	}
+
+	for i, td := range tds {
+		if _, err := NewRay(td.origin, td.direction); err == nil {
+			t.Errorf("test %d failed: expected error, returned nil", i)
+		}
+	}
+}
+
+func TestRayPosition(t *testing.T) {
+	r := Ray{Point(2, 3, 4), Vector(1, 0, 0)}
+
+	type test struct {
+		t        float64
+		expected Tuple
+	}
+
+	tds := []test{
+		{0, Point(2, 3, 4)},
+		{1, Point(3, 3, 4)},
+		{-1, Point(1, 3, 4)},
+		{2.5, Point(4.5, 3, 4)},
+	}
+
+	for i, td := range tds {
+		output := r.Position(td.t)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestRayTransform(t *testing.T) {
+	r := Ray{Point(1, 2, 3), Vector(0, 1, 0)}
+
+	type test struct {
+		m         Matrix
+		origin    Tuple
+		direction Tuple
+	}
+
+	tds := []test{
+		{TranslationMatrix(3, 4, 5), Point(4, 6, 8), Vector(0, 1, 0)},
+		{ScalingMatrix(2, 3, 4), Point(2, 6, 12), Vector(0, 3, 0)},
+	}
+
+	for i, td := range tds {
+		output := r.Transform(td.m)
+		if !output.Origin.Equal(td.origin, epsilon) {
+			t.Errorf("test %d failed: expected origin %v, returned %v", i, td.origin, output.Origin)
+		}
+		if !output.Direction.Equal(td.direction, epsilon) {
+			t.Errorf("test %d failed: expected direction %v, returned %v", i, td.direction, output.Direction)
+		}
+	}
+
+	// original ray is left unchanged
+	if !r.Origin.Equal(Point(1, 2, 3), epsilon) || !r.Direction.Equal(Vector(0, 1, 0), epsilon) {
+		t.Errorf("expected ray to be unchanged, returned %v", r)
+	}
+}
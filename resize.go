@@ -0,0 +1,143 @@
+package tracer
+
+import "math"
+
+// Filter is the reconstruction filter used to resample a canvas.
+type Filter int
+
+const (
+	// BoxFilter averages the pixels covered by each output pixel, or
+	// picks the nearest pixel when enlarging.
+	BoxFilter Filter = iota
+	// BilinearFilter interpolates linearly between neighboring pixels.
+	BilinearFilter
+	// LanczosFilter uses a three lobed Lanczos window, which keeps more
+	// detail but may ring around sharp edges.
+	LanczosFilter
+)
+
+// radius returns the distance beyond which a filter is zero.
+func (f Filter) radius() float64 {
+	switch f {
+	case BilinearFilter:
+		return 1
+	case LanczosFilter:
+		return 3
+	default:
+		return 0.5
+	}
+}
+
+// weight returns the value of a filter at a distance from its center.
+func (f Filter) weight(x float64) float64 {
+	switch f {
+	case BilinearFilter:
+		return math.Max(1-math.Abs(x), 0)
+	case LanczosFilter:
+		if x <= -3 || x >= 3 {
+			return 0
+		}
+		return sinc(x) * sinc(x/3)
+	default:
+		if x >= -0.5 && x < 0.5 {
+			return 1
+		}
+		return 0
+	}
+}
+
+// sinc is the normalized sinc function, sin(pi x) / (pi x).
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	x *= math.Pi
+	return math.Sin(x) / x
+}
+
+// Resize returns a copy of a canvas resampled to width by height pixels
+// with a filter, returning an empty canvas if either size is not
+// positive. Pixels past the edges repeat the edge pixels.
+func (c Canvas) Resize(width, height int, f Filter) Canvas {
+	if width < 1 || height < 1 || c.Width() == 0 {
+		return Canvas{}
+	}
+
+	// resample rows, then columns
+	xs := resampleWeights(c.Width(), width, f)
+	rows := NewCanvas(width, c.Height())
+	for y, row := range c {
+		for x, ws := range xs {
+			rows[y][x] = resample(ws, func(i int) Tuple { return row[i] })
+		}
+	}
+
+	ys := resampleWeights(c.Height(), height, f)
+	out := NewCanvas(width, height)
+	for y, ws := range ys {
+		for x := range out[y] {
+			out[y][x] = resample(ws, func(i int) Tuple { return rows[i][x] })
+		}
+	}
+
+	return out
+}
+
+// filterWeight is the weight of a source pixel in an output pixel.
+type filterWeight struct {
+	index  int
+	weight float64
+}
+
+// resampleWeights returns the weights of the source pixels contributing
+// to each output pixel when resampling from src to dst pixels.
+func resampleWeights(src, dst int, f Filter) [][]filterWeight {
+	scale := float64(src) / float64(dst)
+
+	// stretch the filter when shrinking so that every source pixel
+	// contributes to the output
+	fscale := math.Max(scale, 1)
+	support := f.radius() * fscale
+
+	out := make([][]filterWeight, dst)
+	for i := range out {
+		center := (float64(i)+0.5)*scale - 0.5
+
+		sum := 0.
+		for j := int(math.Floor(center - support)); j <= int(math.Ceil(center+support)); j++ {
+			w := f.weight((float64(j) - center) / fscale)
+			if w == 0 {
+				continue
+			}
+
+			index := j
+			if index < 0 {
+				index = 0
+			}
+			if index > src-1 {
+				index = src - 1
+			}
+			out[i] = append(out[i], filterWeight{index, w})
+			sum += w
+		}
+
+		// normalize the weights so that flat colors are unchanged
+		for j := range out[i] {
+			out[i][j].weight /= sum
+		}
+	}
+
+	return out
+}
+
+// resample returns the weighted sum of source pixels.
+func resample(ws []filterWeight, pixel func(i int) Tuple) Tuple {
+	out := Color(0, 0, 0)
+	for _, w := range ws {
+		t := pixel(w.index)
+		for k := range out {
+			out[k] += t[k] * w.weight
+		}
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package tracer
+
+import "testing"
+
+func TestResize(t *testing.T) {
+	type test struct {
+		c             Canvas
+		width, height int
+		f             Filter
+		expected      Canvas
+	}
+
+	tds := []test{
+		// shrinking with a box filter averages pixels
+		{grayCanvas([][]float64{{0, 2, 4, 6}, {2, 4, 6, 8}}), 2, 1, BoxFilter,
+			grayCanvas([][]float64{{2, 6}})},
+		// enlarging with a box filter repeats pixels
+		{grayCanvas([][]float64{{1, 3}}), 4, 2, BoxFilter,
+			grayCanvas([][]float64{{1, 1, 3, 3}, {1, 1, 3, 3}})},
+		// bilinear interpolation, repeating edge pixels
+		{grayCanvas([][]float64{{0, 4}}), 4, 1, BilinearFilter,
+			grayCanvas([][]float64{{0, 1, 3, 4}})},
+		{grayCanvas([][]float64{{0, 4, 8, 12}}), 2, 1, BilinearFilter,
+			grayCanvas([][]float64{{2.5, 9.5}})},
+		// Lanczos passes through the original pixels at integer scales
+		{grayCanvas([][]float64{{1, 5, 2}}), 3, 1, LanczosFilter,
+			grayCanvas([][]float64{{1, 5, 2}})},
+		{grayCanvas([][]float64{{1, 5, 2}}), 0, 1, LanczosFilter, Canvas{}},
+	}
+
+	for i, td := range tds {
+		output := td.c.Resize(td.width, td.height, td.f)
+		if !canvasEqual(output, td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestResizeFlat(t *testing.T) {
+	c := NewCanvas(7, 5)
+	for y := range c {
+		for x := range c[y] {
+			c.WritePixel(x, y, Color(0.2, 0.4, 0.6))
+		}
+	}
+
+	// every filter keeps a flat color unchanged
+	for _, f := range []Filter{BoxFilter, BilinearFilter, LanczosFilter} {
+		for _, size := range [][2]int{{3, 2}, {7, 5}, {16, 11}} {
+			output := c.Resize(size[0], size[1], f)
+			if output.Width() != size[0] || output.Height() != size[1] {
+				t.Errorf("filter %d: expected %dx%d, returned %dx%d", f, size[0], size[1], output.Width(), output.Height())
+				continue
+			}
+			for y := range output {
+				for x := range output[y] {
+					if !output[y][x].Equal(Color(0.2, 0.4, 0.6), Epsilon) {
+						t.Errorf("filter %d: pixel %d,%d: expected %v, returned %v", f, x, y, Color(0.2, 0.4, 0.6), output[y][x])
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package tracer
+
+// Shape is an object that can be intersected by a ray. Shapes define
+// intersections and normals in their own object space; Intersect and
+// NormalAt convert to and from world space using the shape's transform
+// and the transforms of the groups containing it.
+type Shape interface {
+	Transform() Matrix
+	SetTransform(m Matrix) error
+	Material() *Material
+	// Parent returns the shape containing a shape, or nil.
+	Parent() Shape
+
+	// LocalIntersect intersects a ray already transformed into object space.
+	LocalIntersect(r Ray) Intersections
+	// LocalNormalAt returns the normal at a point in object space, given
+	// the intersection that produced the point.
+	LocalNormalAt(p Tuple, hit Intersection) Tuple
+	// Bounds returns the bounds of a shape in object space.
+	Bounds() Bounds
+
+	inverse() Matrix
+	setParent(p Shape)
+}
+
+// shape holds the transform and material common to every shape.
+type shape struct {
+	transform        Matrix
+	transformInverse Matrix
+	material         Material
+	parent           Shape
+}
+
+// newShape creates a shape with an identity transform and the default
+// material.
+func newShape() shape {
+	return shape{
+		transform:        IdentityMatrix(4),
+		transformInverse: IdentityMatrix(4),
+		material:         DefaultMaterial(),
+	}
+}
+
+// Transform returns the transformation matrix of a shape.
+func (s *shape) Transform() Matrix {
+	return s.transform
+}
+
+// SetTransform sets the transformation matrix of a shape, failing if
+// the matrix cannot be inverted. The bounds of any groups containing the
+// shape are recomputed.
+func (s *shape) SetTransform(m Matrix) error {
+	inverse, err := m.Inverse(Epsilon)
+	if err != nil {
+		return err
+	}
+
+	s.transform = m
+	s.transformInverse = inverse
+
+	// the bounds of the containing groups have changed
+	invalidateBounds(s.parent)
+	return nil
+}
+
+// Material returns the material of a shape, which may be modified in place.
+func (s *shape) Material() *Material {
+	return &s.material
+}
+
+// Parent returns the shape containing a shape, or nil.
+func (s *shape) Parent() Shape {
+	return s.parent
+}
+
+// setParent sets the shape containing a shape.
+func (s *shape) setParent(p Shape) {
+	s.parent = p
+}
+
+// inverse returns the cached inverse of the transformation matrix of a shape.
+func (s *shape) inverse() Matrix {
+	return s.transformInverse
+}
+
+// Intersect returns the intersections of a ray with a shape, where the
+// ray is in the space of the shape's parent, or world space if it has none.
+func Intersect(s Shape, r Ray) Intersections {
+	return s.LocalIntersect(r.Transform(s.inverse()))
+}
+
+// NormalAt returns the surface normal of a shape at a point in world space,
+// given the intersection that produced the point.
+func NormalAt(s Shape, p Tuple, hit Intersection) Tuple {
+	return NormalToWorld(s, s.LocalNormalAt(WorldToObject(s, p), hit))
+}
+
+// WorldToObject converts a point in world space to the object space of
+// a shape, passing through the space of each parent.
+func WorldToObject(s Shape, p Tuple) Tuple {
+	if s.Parent() != nil {
+		p = WorldToObject(s.Parent(), p)
+	}
+
+	return s.inverse().MultiplyT(p)
+}
+
+// NormalToWorld converts a normal in the object space of a shape to world
+// space, passing through the space of each parent.
+func NormalToWorld(s Shape, n Tuple) Tuple {
+	// the inverse transpose keeps the normal perpendicular to the surface
+	n = s.inverse().Transpose().MultiplyT(n)
+	n[3] = 0
+	n = n.Normalize()
+
+	if s.Parent() != nil {
+		n = NormalToWorld(s.Parent(), n)
+	}
+
+	return n
+}
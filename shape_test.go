@@ -0,0 +1,86 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+// testShape is a shape recording the ray it was intersected with.
+type testShape struct {
+	shape
+	savedRay Ray
+}
+
+func newTestShape() *testShape {
+	return &testShape{shape: newShape()}
+}
+
+func (s *testShape) LocalIntersect(r Ray) Intersections {
+	s.savedRay = r
+	return nil
+}
+
+func (s *testShape) Bounds() Bounds {
+	return Bounds{Point(-1, -1, -1), Point(1, 1, 1)}
+}
+
+func (s *testShape) LocalNormalAt(p Tuple, _ Intersection) Tuple {
+	return Vector(p.x(), p.y(), p.z())
+}
+
+func TestShapeIntersect(t *testing.T) {
+	type test struct {
+		transform Matrix
+		origin    Tuple
+		direction Tuple
+	}
+
+	tds := []test{
+		{ScalingMatrix(2, 2, 2), Point(0, 0, -2.5), Vector(0, 0, 0.5)},
+		{TranslationMatrix(5, 0, 0), Point(-5, 0, -5), Vector(0, 0, 1)},
+	}
+
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	for i, td := range tds {
+		s := newTestShape()
+		if err := s.SetTransform(td.transform); err != nil {
+			t.Error(err)
+			return
+		}
+
+		Intersect(s, r)
+		if !s.savedRay.Origin.Equal(td.origin, epsilon) {
+			t.Errorf("test %d failed: expected origin %v, returned %v", i, td.origin, s.savedRay.Origin)
+		}
+		if !s.savedRay.Direction.Equal(td.direction, epsilon) {
+			t.Errorf("test %d failed: expected direction %v, returned %v", i, td.direction, s.savedRay.Direction)
+		}
+	}
+}
+
+func TestShapeNormalAt(t *testing.T) {
+	type test struct {
+		transform Matrix
+		point     Tuple
+		expected  Tuple
+	}
+
+	tds := []test{
+		{TranslationMatrix(0, 1, 0), Point(0, 1.70711, -0.70711), Vector(0, 0.70711, -0.70711)},
+		{ScalingMatrix(1, 0.5, 1).Multiply(RotationZMatrix(math.Pi / 5.)),
+			Point(0, math.Sqrt(2)/2., -math.Sqrt(2)/2.), Vector(0, 0.97014, -0.24254)},
+	}
+
+	for i, td := range tds {
+		s := newTestShape()
+		if err := s.SetTransform(td.transform); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output := NormalAt(s, td.point, Intersection{})
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tracer
+
+import "math"
+
+// Sphere is a unit sphere centered at the origin of its object space.
+type Sphere struct {
+	shape
+}
+
+// NewSphere creates a new unit sphere with an identity transform and
+// the default material.
+func NewSphere() *Sphere {
+	return &Sphere{newShape()}
+}
+
+// LocalIntersect returns the intersections of an object space ray with
+// a sphere, in increasing order. A tangent ray returns the same distance
+// twice, and a ray that misses returns no intersections.
+func (s *Sphere) LocalIntersect(r Ray) Intersections {
+	// solve quadratic for the vector from the sphere center to the ray origin
+	sphereToRay := r.Origin.Sub(Point(0, 0, 0))
+	a := r.Direction.Dot(r.Direction)
+	b := 2 * r.Direction.Dot(sphereToRay)
+	c := sphereToRay.Dot(sphereToRay) - 1
+
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	}
+
+	sqrt := math.Sqrt(discriminant)
+	return NewIntersections(
+		Intersection{T: (-b - sqrt) / (2 * a), Object: s},
+		Intersection{T: (-b + sqrt) / (2 * a), Object: s})
+}
+
+// LocalNormalAt returns the normal of a sphere at a point in object space.
+func (s *Sphere) LocalNormalAt(p Tuple, _ Intersection) Tuple {
+	return p.Sub(Point(0, 0, 0))
+}
+
+// Bounds returns the bounds of a sphere in object space.
+func (s *Sphere) Bounds() Bounds {
+	return Bounds{Point(-1, -1, -1), Point(1, 1, 1)}
+}
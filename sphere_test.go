@@ -0,0 +1,125 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSphereIntersect(t *testing.T) {
+	type test struct {
+		ray      Ray
+		expected []float64
+	}
+
+	tds := []test{
+		{Ray{Point(0, 0, -5), Vector(0, 0, 1)}, []float64{4, 6}},
+		{Ray{Point(0, 1, -5), Vector(0, 0, 1)}, []float64{5, 5}},
+		{Ray{Point(0, 2, -5), Vector(0, 0, 1)}, nil},
+		{Ray{Point(0, 0, 0), Vector(0, 0, 1)}, []float64{-1, 1}},
+		{Ray{Point(0, 0, 5), Vector(0, 0, 1)}, []float64{-6, -4}},
+	}
+
+	s := NewSphere()
+	for i, td := range tds {
+		output := Intersect(s, td.ray)
+		if !intersectionsEqual(output, td.expected, s, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestSphereTransform(t *testing.T) {
+	s := NewSphere()
+	if !s.Transform().Equal(IdentityMatrix(4), epsilon) {
+		t.Errorf("expected %v, returned %v", IdentityMatrix(4), s.Transform())
+	}
+
+	m := TranslationMatrix(2, 3, 4)
+	if err := s.SetTransform(m); err != nil {
+		t.Error(err)
+		return
+	}
+	if !s.Transform().Equal(m, epsilon) {
+		t.Errorf("expected %v, returned %v", m, s.Transform())
+	}
+
+	if err := s.SetTransform(ScalingMatrix(0, 1, 1)); err == nil {
+		t.Error("expected error, returned nil")
+	}
+	if !s.Transform().Equal(m, epsilon) {
+		t.Errorf("expected transform to be unchanged, returned %v", s.Transform())
+	}
+}
+
+func TestSphereIntersectTransformed(t *testing.T) {
+	type test struct {
+		transform Matrix
+		expected  []float64
+	}
+
+	tds := []test{
+		{ScalingMatrix(2, 2, 2), []float64{3, 7}},
+		{TranslationMatrix(5, 0, 0), nil},
+	}
+
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	for i, td := range tds {
+		s := NewSphere()
+		if err := s.SetTransform(td.transform); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output := Intersect(s, r)
+		if !intersectionsEqual(output, td.expected, s, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestSphereNormalAt(t *testing.T) {
+	type test struct {
+		transform Matrix
+		point     Tuple
+		expected  Tuple
+	}
+
+	k := math.Sqrt(3) / 3.
+	tds := []test{
+		{IdentityMatrix(4), Point(1, 0, 0), Vector(1, 0, 0)},
+		{IdentityMatrix(4), Point(0, 1, 0), Vector(0, 1, 0)},
+		{IdentityMatrix(4), Point(0, 0, 1), Vector(0, 0, 1)},
+		{IdentityMatrix(4), Point(k, k, k), Vector(k, k, k)},
+		{TranslationMatrix(0, 1, 0), Point(0, 1.70711, -0.70711), Vector(0, 0.70711, -0.70711)},
+		{ScalingMatrix(1, 0.5, 1).Multiply(RotationZMatrix(math.Pi / 5.)),
+			Point(0, math.Sqrt(2)/2., -math.Sqrt(2)/2.), Vector(0, 0.97014, -0.24254)},
+	}
+
+	for i, td := range tds {
+		s := NewSphere()
+		if err := s.SetTransform(td.transform); err != nil {
+			t.Error(err)
+			return
+		}
+
+		output := NormalAt(s, td.point, Intersection{})
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+		if !output.Equal(output.Normalize(), epsilon) {
+			t.Errorf("test %d failed: expected normalized vector, returned %v", i, output)
+		}
+	}
+}
+
+func TestSphereMaterial(t *testing.T) {
+	s := NewSphere()
+	if s.Material().Ambient != 0.1 {
+		t.Errorf("expected 0.1, returned %f", s.Material().Ambient)
+	}
+
+	s.Material().Ambient = 1
+	if s.Material().Ambient != 1 {
+		t.Errorf("expected 1, returned %f", s.Material().Ambient)
+	}
+}
@@ -0,0 +1,126 @@
+package tracer
+
+import "math"
+
+// ToneMapper maps linear high dynamic range colors to linear colors
+// with channels between 0 and 1.
+type ToneMapper interface {
+	ToneMap(c Tuple) Tuple
+}
+
+// ClampToneMapper clamps each color channel between 0 and 1, burning out
+// any highlights.
+type ClampToneMapper struct{}
+
+// ToneMap clamps each channel of a color between 0 and 1.
+func (ClampToneMapper) ToneMap(c Tuple) Tuple {
+	return Color(clamp(c[0]), clamp(c[1]), clamp(c[2]))
+}
+
+// ReinhardToneMapper compresses each color channel with the Reinhard
+// operator, v / (1 + v). If WhitePoint is positive, channels at or above
+// it map to 1.
+type ReinhardToneMapper struct {
+	WhitePoint float64
+}
+
+// ToneMap applies the Reinhard operator to each channel of a color.
+func (tm ReinhardToneMapper) ToneMap(c Tuple) Tuple {
+	out := Color(0, 0, 0)
+	for i := range out {
+		v := math.Max(c[i], 0)
+		if tm.WhitePoint > 0 {
+			out[i] = clamp(v * (1 + v/(tm.WhitePoint*tm.WhitePoint)) / (1 + v))
+		} else {
+			out[i] = v / (1 + v)
+		}
+	}
+	return out
+}
+
+// ACESToneMapper applies Krzysztof Narkowicz's fit of the ACES filmic
+// curve, which gives more contrast than Reinhard.
+type ACESToneMapper struct{}
+
+// ToneMap applies the ACES filmic curve to each channel of a color.
+func (ACESToneMapper) ToneMap(c Tuple) Tuple {
+	out := Color(0, 0, 0)
+	for i := range out {
+		v := math.Max(c[i], 0)
+		out[i] = clamp(v * (2.51*v + 0.03) / (v*(2.43*v+0.59) + 0.14))
+	}
+	return out
+}
+
+// ExposureToneMapper scales colors by 2 to the power of Stops before
+// passing them to ToneMapper, which defaults to ClampToneMapper.
+type ExposureToneMapper struct {
+	Stops      float64
+	ToneMapper ToneMapper
+}
+
+// ToneMap adjusts the exposure of a color and tone maps it.
+func (tm ExposureToneMapper) ToneMap(c Tuple) Tuple {
+	next := tm.ToneMapper
+	if next == nil {
+		next = ClampToneMapper{}
+	}
+	return next.ToneMap(c.Multiply(math.Exp2(tm.Stops)))
+}
+
+// Encoding configures how the linear colors of a canvas are converted
+// to integer values by the low dynamic range image writers. The zero
+// value clamps colors and writes them linearly without dithering.
+type Encoding struct {
+	// ToneMapper maps colors between 0 and 1, defaulting to
+	// ClampToneMapper.
+	ToneMapper ToneMapper
+	// SRGB applies the sRGB transfer function after tone mapping, which
+	// most image viewers expect.
+	SRGB bool
+	// Dither hides banding when quantizing, and is mostly useful for
+	// 8-bit output. It defaults to NoDither.
+	Dither Dither
+}
+
+// encode tone maps a linear color and applies the transfer function,
+// returning channels between 0 and 1.
+func (e Encoding) encode(c Tuple) Tuple {
+	tm := e.ToneMapper
+	if tm == nil {
+		tm = ClampToneMapper{}
+	}
+
+	out := tm.ToneMap(c)
+	for i := range out {
+		// clamp in case a tone mapper allows values out of range
+		out[i] = clamp(out[i])
+		if e.SRGB {
+			out[i] = SRGBEncode(out[i])
+		}
+	}
+	return out
+}
+
+// SRGBEncode applies the sRGB transfer function to a linear value
+// between 0 and 1.
+func SRGBEncode(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// SRGBDecode converts an sRGB encoded value between 0 and 1 to a
+// linear value.
+func SRGBDecode(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// clamp limits a value to between 0 and 1.
+func clamp(v float64) float64 {
+	return math.Min(math.Max(v, 0), 1)
+}
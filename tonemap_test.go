@@ -0,0 +1,87 @@
+package tracer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestToneMap(t *testing.T) {
+	type test struct {
+		tm       ToneMapper
+		color    Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		{ClampToneMapper{}, Color(-0.5, 0.5, 1.5), Color(0, 0.5, 1)},
+		{ReinhardToneMapper{}, Color(-1, 1, 3), Color(0, 0.5, 0.75)},
+		{ReinhardToneMapper{WhitePoint: 4}, Color(1, 4, 8), Color(0.53125, 1, 1)},
+		{ACESToneMapper{}, Color(-1, 0, 1), Color(0, 0, 0.80377)},
+		{ACESToneMapper{}, Color(0.18, 100, 0), Color(0.26689, 1, 0)},
+		{ExposureToneMapper{Stops: 1}, Color(0.25, 0.5, 1), Color(0.5, 1, 1)},
+		{ExposureToneMapper{Stops: -2, ToneMapper: ReinhardToneMapper{}}, Color(4, 12, 0), Color(0.5, 0.75, 0)},
+	}
+
+	for i, td := range tds {
+		output := td.tm.ToneMap(td.color)
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestSRGB(t *testing.T) {
+	type test struct {
+		linear, encoded float64
+	}
+
+	tds := []test{
+		{0, 0},
+		{0.0031308, 0.04045},
+		{0.18, 0.46135},
+		{0.5, 0.73536},
+		{1, 1},
+	}
+
+	for i, td := range tds {
+		if output := SRGBEncode(td.linear); !eq(output, td.encoded, Epsilon) {
+			t.Errorf("test %d failed: expected %f, returned %f", i, td.encoded, output)
+		}
+		if output := SRGBDecode(td.encoded); !eq(output, td.linear, Epsilon) {
+			t.Errorf("test %d failed: expected %f, returned %f", i, td.linear, output)
+		}
+	}
+}
+
+func TestWritePPMEncoding(t *testing.T) {
+	c := NewCanvas(3, 1)
+	c.WritePixel(0, 0, Color(0.5, 0.18, 0))
+	c.WritePixel(1, 0, Color(2, 4, -1))
+	c.WritePixel(2, 0, Color(0.25, 0.5, 1))
+
+	type test struct {
+		opt      PPMOptions
+		expected string
+	}
+
+	tds := []test{
+		{PPMOptions{}, "P3\n3 1\n255\n128 46 0 255 255 0 64 128 255"},
+		{PPMOptions{Encoding: Encoding{SRGB: true}}, "P3\n3 1\n255\n188 118 0 255 255 0 137 188 255"},
+		{PPMOptions{Encoding: Encoding{ToneMapper: ReinhardToneMapper{}}}, "P3\n3 1\n255\n85 39 0 170 204 0 51 85 128"},
+		{PPMOptions{Encoding: Encoding{ToneMapper: ExposureToneMapper{Stops: -1}}}, "P3\n3 1\n255\n64 23 0 255 255 0 32 64 128"},
+	}
+
+	for i, td := range tds {
+		var b bytes.Buffer
+		if err := c.WritePPM(&b, td.opt); err != nil {
+			t.Error(err)
+			return
+		}
+		if b.String() != td.expected {
+			t.Errorf("test %d failed: expected %q, returned %q", i, td.expected, b.String())
+		}
+		if s := c.ToPPMEncoded(td.opt.Encoding); s != td.expected {
+			t.Errorf("test %d failed: expected ToPPMEncoded to return %q, returned %q", i, td.expected, s)
+		}
+	}
+}
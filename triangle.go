@@ -0,0 +1,164 @@
+package tracer
+
+import "math"
+
+// Triangle is a flat triangle between three points in object space. The
+// edge vectors and normal are precomputed from the points on creation,
+// so the points cannot be changed.
+type Triangle struct {
+	shape
+	p1, p2, p3 Tuple
+	e1, e2     Tuple
+	normal     Tuple
+}
+
+// NewTriangle creates a new triangle between three points with an
+// identity transform and the default material.
+func NewTriangle(p1, p2, p3 Tuple) *Triangle {
+	e1 := p2.Sub(p1)
+	e2 := p3.Sub(p1)
+
+	return &Triangle{
+		shape:  newShape(),
+		p1:     p1,
+		p2:     p2,
+		p3:     p3,
+		e1:     e1,
+		e2:     e2,
+		normal: e2.Cross(e1).Normalize(),
+	}
+}
+
+// P1 returns the first point of a triangle.
+func (t *Triangle) P1() Tuple {
+	return t.p1
+}
+
+// P2 returns the second point of a triangle.
+func (t *Triangle) P2() Tuple {
+	return t.p2
+}
+
+// P3 returns the third point of a triangle.
+func (t *Triangle) P3() Tuple {
+	return t.p3
+}
+
+// LocalIntersect returns the intersection of an object space ray with
+// a triangle.
+func (t *Triangle) LocalIntersect(r Ray) Intersections {
+	return intersectTriangle(t, r, t.p1, t.e1, t.e2)
+}
+
+// LocalNormalAt returns the normal of a triangle, which is the same at
+// every point.
+func (t *Triangle) LocalNormalAt(_ Tuple, _ Intersection) Tuple {
+	return t.normal
+}
+
+// Bounds returns the bounds of a triangle in object space.
+func (t *Triangle) Bounds() Bounds {
+	return EmptyBounds().AddPoint(t.p1).AddPoint(t.p2).AddPoint(t.p3)
+}
+
+// SmoothTriangle is a triangle with a normal at each of its vertices,
+// interpolated across its surface to approximate a curved surface. Like
+// Triangle, its points and normals cannot be changed after creation.
+type SmoothTriangle struct {
+	shape
+	p1, p2, p3 Tuple
+	n1, n2, n3 Tuple
+	e1, e2     Tuple
+}
+
+// NewSmoothTriangle creates a new triangle between three points with
+// a normal at each point, an identity transform and the default material.
+func NewSmoothTriangle(p1, p2, p3, n1, n2, n3 Tuple) *SmoothTriangle {
+	return &SmoothTriangle{
+		shape: newShape(),
+		p1:    p1,
+		p2:    p2,
+		p3:    p3,
+		n1:    n1,
+		n2:    n2,
+		n3:    n3,
+		e1:    p2.Sub(p1),
+		e2:    p3.Sub(p1),
+	}
+}
+
+// P1 returns the first point of a smooth triangle.
+func (t *SmoothTriangle) P1() Tuple {
+	return t.p1
+}
+
+// P2 returns the second point of a smooth triangle.
+func (t *SmoothTriangle) P2() Tuple {
+	return t.p2
+}
+
+// P3 returns the third point of a smooth triangle.
+func (t *SmoothTriangle) P3() Tuple {
+	return t.p3
+}
+
+// N1 returns the normal at the first point of a smooth triangle.
+func (t *SmoothTriangle) N1() Tuple {
+	return t.n1
+}
+
+// N2 returns the normal at the second point of a smooth triangle.
+func (t *SmoothTriangle) N2() Tuple {
+	return t.n2
+}
+
+// N3 returns the normal at the third point of a smooth triangle.
+func (t *SmoothTriangle) N3() Tuple {
+	return t.n3
+}
+
+// LocalIntersect returns the intersection of an object space ray with
+// a smooth triangle, recording where it hit relative to the vertices.
+func (t *SmoothTriangle) LocalIntersect(r Ray) Intersections {
+	return intersectTriangle(t, r, t.p1, t.e1, t.e2)
+}
+
+// LocalNormalAt returns the normal of a smooth triangle at the location
+// of an intersection, interpolated between the vertex normals.
+func (t *SmoothTriangle) LocalNormalAt(_ Tuple, hit Intersection) Tuple {
+	return t.n2.Multiply(hit.U).
+		Add(t.n3.Multiply(hit.V)).
+		Add(t.n1.Multiply(1 - hit.U - hit.V))
+}
+
+// Bounds returns the bounds of a smooth triangle in object space.
+func (t *SmoothTriangle) Bounds() Bounds {
+	return EmptyBounds().AddPoint(t.p1).AddPoint(t.p2).AddPoint(t.p3)
+}
+
+// intersectTriangle returns the intersection of an object space ray with
+// the triangle at p1 spanned by edges e1 and e2, using the Möller–Trumbore
+// algorithm. The intersection records the barycentric u and v of the hit.
+func intersectTriangle(s Shape, r Ray, p1, e1, e2 Tuple) Intersections {
+	// a ray parallel to the triangle cannot hit it
+	dirCrossE2 := r.Direction.Cross(e2)
+	det := e1.Dot(dirCrossE2)
+	if math.Abs(det) < Epsilon {
+		return nil
+	}
+
+	f := 1 / det
+	p1ToOrigin := r.Origin.Sub(p1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return nil
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(e1)
+	v := f * r.Direction.Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return nil
+	}
+
+	return Intersections{{T: f * e2.Dot(originCrossE1), Object: s, U: u, V: v}}
+}
@@ -0,0 +1,79 @@
+package tracer
+
+import "testing"
+
+func TestNewTriangle(t *testing.T) {
+	tri := NewTriangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0))
+
+	if !tri.e1.Equal(Vector(-1, -1, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(-1, -1, 0), tri.e1)
+	}
+	if !tri.e2.Equal(Vector(1, -1, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(1, -1, 0), tri.e2)
+	}
+	if !tri.normal.Equal(Vector(0, 0, -1), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0, 0, -1), tri.normal)
+	}
+
+	for i, p := range []Tuple{Point(0, 0.5, 0), Point(-0.5, 0.75, 0), Point(0.5, 0.25, 0)} {
+		output := tri.LocalNormalAt(p, Intersection{})
+		if !output.Equal(tri.normal, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, tri.normal, output)
+		}
+	}
+}
+
+func TestTriangleIntersect(t *testing.T) {
+	type test struct {
+		ray      Ray
+		expected []float64
+	}
+
+	tds := []test{
+		// parallel to the triangle
+		{Ray{Point(0, -1, -2), Vector(0, 1, 0)}, nil},
+		// misses each edge
+		{Ray{Point(1, 1, -2), Vector(0, 0, 1)}, nil},
+		{Ray{Point(-1, 1, -2), Vector(0, 0, 1)}, nil},
+		{Ray{Point(0, -1, -2), Vector(0, 0, 1)}, nil},
+		// hits
+		{Ray{Point(0, 0.5, -2), Vector(0, 0, 1)}, []float64{2}},
+	}
+
+	tri := NewTriangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0))
+	for i, td := range tds {
+		output := tri.LocalIntersect(td.ray)
+		if !intersectionsEqual(output, td.expected, tri, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestSmoothTriangle(t *testing.T) {
+	tri := NewSmoothTriangle(Point(0, 1, 0), Point(-1, 0, 0), Point(1, 0, 0),
+		Vector(0, 1, 0), Vector(-1, 0, 0), Vector(1, 0, 0))
+
+	// intersection stores u and v
+	xs := tri.LocalIntersect(Ray{Point(-0.2, 0.3, -2), Vector(0, 0, 1)})
+	if len(xs) != 1 {
+		t.Errorf("expected 1 intersection, returned %d", len(xs))
+		return
+	}
+	if !eq(xs[0].U, 0.45, Epsilon) || !eq(xs[0].V, 0.25, Epsilon) {
+		t.Errorf("expected u=0.45 v=0.25, returned u=%f v=%f", xs[0].U, xs[0].V)
+	}
+
+	// normal is interpolated from u and v
+	hit := Intersection{T: 1, Object: tri, U: 0.45, V: 0.25}
+	output := NormalAt(tri, Point(0, 0, 0), hit)
+	if !output.Equal(Vector(-0.5547, 0.83205, 0), Epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(-0.5547, 0.83205, 0), output)
+	}
+
+	// prepared computations use the interpolated normal
+	r := Ray{Point(-0.2, 0.3, -2), Vector(0, 0, 1)}
+	comps := PrepareComputations(hit, r, Intersections{hit})
+	if !comps.NormalV.Equal(Vector(-0.5547, 0.83205, 0), Epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(-0.5547, 0.83205, 0), comps.NormalV)
+	}
+}
@@ -2,6 +2,10 @@ package tracer
 
 import "math"
 
+// Epsilon is the tolerance used when comparing floating point values
+// produced while tracing a scene.
+const Epsilon = 0.0001
+
 // Tuple represents a coordinate with some number of values.
 type Tuple []float64
 
@@ -92,3 +92,23 @@ func TestTick(t *testing.T) {
 		t.Errorf("expected 18, return %d", len(ps))
 	}
 }
+
+func TestReflect(t *testing.T) {
+	type test struct {
+		input    Tuple
+		normal   Tuple
+		expected Tuple
+	}
+
+	tds := []test{
+		{Vector(1, -1, 0), Vector(0, 1, 0), Vector(1, 1, 0)},
+		{Vector(0, -1, 0), Vector(math.Sqrt(2)/2., math.Sqrt(2)/2., 0), Vector(1, 0, 0)},
+	}
+
+	for i, td := range tds {
+		output := td.input.Reflect(td.normal)
+		if !output.Equal(td.expected, epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
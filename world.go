@@ -0,0 +1,245 @@
+package tracer
+
+import "math"
+
+// DefaultMaxDepth is the default number of times a ray may be reflected
+// or refracted before further bounces are ignored.
+const DefaultMaxDepth = 5
+
+// World is a collection of objects and the lights illuminating them.
+type World struct {
+	Objects []Shape
+	Lights  []PointLight
+	// MaxDepth limits how many times a ray may be reflected or refracted,
+	// so that surfaces reflecting each other do not recurse forever. Zero
+	// means DefaultMaxDepth, and a negative MaxDepth renders no
+	// reflections or refractions.
+	MaxDepth int
+}
+
+// DefaultWorld returns a world with two concentric spheres lit by a
+// single white light.
+func DefaultWorld() World {
+	s1 := NewSphere()
+	s1.Material().Color = Color(0.8, 1.0, 0.6)
+	s1.Material().Diffuse = 0.7
+	s1.Material().Specular = 0.2
+
+	s2 := NewSphere()
+	s2.SetTransform(ScalingMatrix(0.5, 0.5, 0.5))
+
+	return World{
+		Objects: []Shape{s1, s2},
+		Lights:  []PointLight{{Point(-10, 10, -10), Color(1, 1, 1)}},
+	}
+}
+
+// IntersectWorld returns the sorted intersections of a ray with every
+// object in a world.
+func (w World) IntersectWorld(r Ray) Intersections {
+	var xs Intersections
+	for _, o := range w.Objects {
+		xs = xs.Add(Intersect(o, r)...)
+	}
+
+	return xs
+}
+
+// Computations holds precomputed values about an intersection used
+// when shading it.
+type Computations struct {
+	T      float64
+	Object Shape
+	Point  Tuple
+	// OverPoint is Point nudged slightly along the normal, used to
+	// avoid an object shadowing itself.
+	OverPoint Tuple
+	// UnderPoint is Point nudged slightly below the surface, used as the
+	// origin of refracted rays.
+	UnderPoint Tuple
+	EyeV       Tuple
+	NormalV    Tuple
+	ReflectV   Tuple
+	Inside     bool
+	// N1 and N2 are the refractive indices of the materials on either
+	// side of the intersection.
+	N1 float64
+	N2 float64
+}
+
+// PrepareComputations computes the values needed to shade an intersection
+// of a ray with an object, given all intersections of the ray, which are
+// needed to find the materials the ray is passing between.
+func PrepareComputations(i Intersection, r Ray, xs Intersections) Computations {
+	comps := Computations{
+		T:      i.T,
+		Object: i.Object,
+		Point:  r.Position(i.T),
+		EyeV:   r.Direction.Negate(),
+	}
+	comps.NormalV = NormalAt(i.Object, comps.Point, i)
+
+	// flip the normal if the ray originates inside the object
+	if comps.NormalV.Dot(comps.EyeV) < 0 {
+		comps.Inside = true
+		comps.NormalV = comps.NormalV.Negate()
+	}
+
+	comps.ReflectV = r.Direction.Reflect(comps.NormalV)
+	comps.OverPoint = comps.Point.Add(comps.NormalV.Multiply(Epsilon))
+	comps.UnderPoint = comps.Point.Sub(comps.NormalV.Multiply(Epsilon))
+	comps.N1, comps.N2 = refractiveIndices(i, xs)
+
+	return comps
+}
+
+// refractiveIndices returns the refractive indices of the materials being
+// exited and entered at an intersection, tracking which objects contain
+// each of the intersections leading up to it.
+func refractiveIndices(hit Intersection, xs Intersections) (float64, float64) {
+	var containers []Shape
+	n1, n2 := 1., 1.
+
+	for _, i := range xs {
+		if i == hit && len(containers) > 0 {
+			n1 = containers[len(containers)-1].Material().RefractiveIndex
+		}
+
+		// the ray either leaves or enters the object
+		exited := false
+		for j, c := range containers {
+			if c == i.Object {
+				containers = append(containers[:j], containers[j+1:]...)
+				exited = true
+				break
+			}
+		}
+		if !exited {
+			containers = append(containers, i.Object)
+		}
+
+		if i == hit {
+			if len(containers) > 0 {
+				n2 = containers[len(containers)-1].Material().RefractiveIndex
+			}
+			break
+		}
+	}
+
+	return n1, n2
+}
+
+// ShadeHit returns the color at a precomputed intersection, summing the
+// contribution of each light in the world, and any reflected and refracted
+// light. Remaining is the number of further reflections or refractions
+// allowed.
+func (w World) ShadeHit(comps Computations, remaining int) Tuple {
+	surface := Color(0, 0, 0)
+	for _, l := range w.Lights {
+		surface = surface.Add(Lighting(*comps.Object.Material(), comps.Object, l,
+			comps.OverPoint, comps.EyeV, comps.NormalV, w.IsShadowed(comps.OverPoint, l)))
+	}
+
+	reflected := w.ReflectedColor(comps, remaining)
+	refracted := w.RefractedColor(comps, remaining)
+
+	// surfaces both reflecting and refracting reflect more light at
+	// shallow angles
+	m := comps.Object.Material()
+	if m.Reflective > 0 && m.Transparency > 0 {
+		reflectance := Schlick(comps)
+		return surface.Add(reflected.Multiply(reflectance)).Add(refracted.Multiply(1 - reflectance))
+	}
+
+	return surface.Add(reflected).Add(refracted)
+}
+
+// ColorAt returns the color seen along a ray, or black if the ray does
+// not hit anything.
+func (w World) ColorAt(r Ray) Tuple {
+	depth := w.MaxDepth
+	if depth == 0 {
+		depth = DefaultMaxDepth
+	}
+	return w.colorAt(r, depth)
+}
+
+// colorAt returns the color seen along a ray, allowing a number of
+// further reflections or refractions.
+func (w World) colorAt(r Ray, remaining int) Tuple {
+	xs := w.IntersectWorld(r)
+	hit, ok := xs.Hit()
+	if !ok {
+		return Color(0, 0, 0)
+	}
+
+	return w.ShadeHit(PrepareComputations(hit, r, xs), remaining)
+}
+
+// ReflectedColor returns the color reflected by a precomputed intersection,
+// or black if the surface is not reflective or no reflections remain.
+func (w World) ReflectedColor(comps Computations, remaining int) Tuple {
+	reflective := comps.Object.Material().Reflective
+	if reflective == 0 || remaining <= 0 {
+		return Color(0, 0, 0)
+	}
+
+	r := Ray{Origin: comps.OverPoint, Direction: comps.ReflectV}
+	return w.colorAt(r, remaining-1).Multiply(reflective)
+}
+
+// RefractedColor returns the color refracted through a precomputed
+// intersection, or black if the surface is opaque, no refractions remain,
+// or the light is totally internally reflected.
+func (w World) RefractedColor(comps Computations, remaining int) Tuple {
+	transparency := comps.Object.Material().Transparency
+	if transparency == 0 || remaining <= 0 {
+		return Color(0, 0, 0)
+	}
+
+	// find the angle of the refracted ray with Snell's law
+	nRatio := comps.N1 / comps.N2
+	cosI := comps.EyeV.Dot(comps.NormalV)
+	sin2T := nRatio * nRatio * (1 - cosI*cosI)
+	if sin2T > 1 {
+		return Color(0, 0, 0)
+	}
+
+	cosT := math.Sqrt(1 - sin2T)
+	direction := comps.NormalV.Multiply(nRatio*cosI - cosT).Sub(comps.EyeV.Multiply(nRatio))
+
+	r := Ray{Origin: comps.UnderPoint, Direction: direction}
+	return w.colorAt(r, remaining-1).Multiply(transparency)
+}
+
+// Schlick approximates the fraction of light reflected by a precomputed
+// intersection, given the refractive indices on either side of it.
+func Schlick(comps Computations) float64 {
+	cos := comps.EyeV.Dot(comps.NormalV)
+
+	// total internal reflection reflects all light
+	if comps.N1 > comps.N2 {
+		n := comps.N1 / comps.N2
+		sin2T := n * n * (1 - cos*cos)
+		if sin2T > 1 {
+			return 1
+		}
+
+		// use the angle of the refracted ray instead
+		cos = math.Sqrt(1 - sin2T)
+	}
+
+	r0 := math.Pow((comps.N1-comps.N2)/(comps.N1+comps.N2), 2)
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
+
+// IsShadowed returns true if an object lies between a point and a light.
+func (w World) IsShadowed(p Tuple, l PointLight) bool {
+	v := l.Position.Sub(p)
+	distance := v.Magnitude()
+
+	r := Ray{Origin: p, Direction: v.Normalize()}
+	hit, ok := w.IntersectWorld(r).Hit()
+
+	return ok && hit.T < distance
+}
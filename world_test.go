@@ -0,0 +1,429 @@
+package tracer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntersectWorld(t *testing.T) {
+	w := DefaultWorld()
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+
+	xs := w.IntersectWorld(r)
+	expected := []float64{4, 4.5, 5.5, 6}
+	if len(xs) != len(expected) {
+		t.Errorf("expected %d intersections, returned %d", len(expected), len(xs))
+		return
+	}
+	for i := range xs {
+		if !eq(xs[i].T, expected[i], epsilon) {
+			t.Errorf("test %d failed: expected %f, returned %f", i, expected[i], xs[i].T)
+		}
+	}
+}
+
+func TestPrepareComputations(t *testing.T) {
+	s := NewSphere()
+
+	// hit on the outside
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	comps := PrepareComputations(Intersection{T: 4, Object: s}, r, Intersections{Intersection{T: 4, Object: s}})
+	if comps.Object != s || comps.T != 4 {
+		t.Errorf("expected intersection values to be copied, returned %v", comps)
+	}
+	if !comps.Point.Equal(Point(0, 0, -1), epsilon) {
+		t.Errorf("expected %v, returned %v", Point(0, 0, -1), comps.Point)
+	}
+	if !comps.EyeV.Equal(Vector(0, 0, -1), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0, 0, -1), comps.EyeV)
+	}
+	if !comps.NormalV.Equal(Vector(0, 0, -1), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0, 0, -1), comps.NormalV)
+	}
+	if comps.Inside {
+		t.Error("expected outside hit, returned inside")
+	}
+
+	// hit on the inside
+	r = Ray{Point(0, 0, 0), Vector(0, 0, 1)}
+	comps = PrepareComputations(Intersection{T: 1, Object: s}, r, Intersections{Intersection{T: 1, Object: s}})
+	if !comps.Point.Equal(Point(0, 0, 1), epsilon) {
+		t.Errorf("expected %v, returned %v", Point(0, 0, 1), comps.Point)
+	}
+	if !comps.NormalV.Equal(Vector(0, 0, -1), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0, 0, -1), comps.NormalV)
+	}
+	if !comps.Inside {
+		t.Error("expected inside hit, returned outside")
+	}
+}
+
+func TestOverPoint(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(TranslationMatrix(0, 0, 1))
+
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	comps := PrepareComputations(Intersection{T: 5, Object: s}, r, Intersections{Intersection{T: 5, Object: s}})
+	if comps.OverPoint.z() >= -Epsilon/2 {
+		t.Errorf("expected over point below %f, returned %f", -Epsilon/2, comps.OverPoint.z())
+	}
+	if comps.Point.z() <= comps.OverPoint.z() {
+		t.Errorf("expected over point above point, returned %v %v", comps.OverPoint, comps.Point)
+	}
+}
+
+func TestShadeHit(t *testing.T) {
+	// outside
+	w := DefaultWorld()
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	output := w.ShadeHit(PrepareComputations(Intersection{T: 4, Object: w.Objects[0]}, r, Intersections{Intersection{T: 4, Object: w.Objects[0]}}), DefaultMaxDepth)
+	if !output.Equal(Color(0.38066, 0.47583, 0.2855), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.38066, 0.47583, 0.2855), output)
+	}
+
+	// inside
+	w.Lights = []PointLight{{Point(0, 0.25, 0), Color(1, 1, 1)}}
+	r = Ray{Point(0, 0, 0), Vector(0, 0, 1)}
+	output = w.ShadeHit(PrepareComputations(Intersection{T: 0.5, Object: w.Objects[1]}, r, Intersections{Intersection{T: 0.5, Object: w.Objects[1]}}), DefaultMaxDepth)
+	if !output.Equal(Color(0.90498, 0.90498, 0.90498), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.90498, 0.90498, 0.90498), output)
+	}
+
+	// in shadow
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransform(TranslationMatrix(0, 0, 10))
+	w = World{
+		Objects: []Shape{s1, s2},
+		Lights:  []PointLight{{Point(0, 0, -10), Color(1, 1, 1)}},
+	}
+	r = Ray{Point(0, 0, 5), Vector(0, 0, 1)}
+	output = w.ShadeHit(PrepareComputations(Intersection{T: 4, Object: s2}, r, Intersections{Intersection{T: 4, Object: s2}}), DefaultMaxDepth)
+	if !output.Equal(Color(0.1, 0.1, 0.1), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.1, 0.1, 0.1), output)
+	}
+
+	// multiple lights are summed
+	w = DefaultWorld()
+	w.Lights = append(w.Lights, w.Lights[0])
+	r = Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	output = w.ShadeHit(PrepareComputations(Intersection{T: 4, Object: w.Objects[0]}, r, Intersections{Intersection{T: 4, Object: w.Objects[0]}}), DefaultMaxDepth)
+	if !output.Equal(Color(0.76132, 0.95166, 0.5710), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.76132, 0.95166, 0.5710), output)
+	}
+}
+
+func TestColorAt(t *testing.T) {
+	type test struct {
+		ray      Ray
+		expected Tuple
+	}
+
+	tds := []test{
+		{Ray{Point(0, 0, -5), Vector(0, 1, 0)}, Color(0, 0, 0)},
+		{Ray{Point(0, 0, -5), Vector(0, 0, 1)}, Color(0.38066, 0.47583, 0.2855)},
+	}
+
+	w := DefaultWorld()
+	for i, td := range tds {
+		output := w.ColorAt(td.ray)
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+
+	// intersection behind the ray
+	w.Objects[0].Material().Ambient = 1
+	w.Objects[1].Material().Ambient = 1
+	output := w.ColorAt(Ray{Point(0, 0, 0.75), Vector(0, 0, -1)})
+	if !output.Equal(w.Objects[1].Material().Color, Epsilon) {
+		t.Errorf("expected %v, returned %v", w.Objects[1].Material().Color, output)
+	}
+}
+
+func TestIsShadowed(t *testing.T) {
+	type test struct {
+		point    Tuple
+		expected bool
+	}
+
+	tds := []test{
+		{Point(0, 10, 0), false},
+		{Point(10, -10, 10), true},
+		{Point(-20, 20, -20), false},
+		{Point(-2, 2, -2), false},
+	}
+
+	w := DefaultWorld()
+	for i, td := range tds {
+		output := w.IsShadowed(td.point, w.Lights[0])
+		if output != td.expected {
+			t.Errorf("test %d failed: expected %t, returned %t", i, td.expected, output)
+		}
+	}
+}
+
+func TestReflectV(t *testing.T) {
+	k := math.Sqrt(2) / 2.
+	p := NewPlane()
+	r := Ray{Point(0, 1, -1), Vector(0, -k, k)}
+
+	comps := PrepareComputations(Intersection{T: math.Sqrt(2), Object: p}, r, Intersections{Intersection{T: math.Sqrt(2), Object: p}})
+	if !comps.ReflectV.Equal(Vector(0, k, k), epsilon) {
+		t.Errorf("expected %v, returned %v", Vector(0, k, k), comps.ReflectV)
+	}
+}
+
+func TestReflectedColor(t *testing.T) {
+	k := math.Sqrt(2) / 2.
+
+	// nonreflective material
+	w := DefaultWorld()
+	w.Objects[1].Material().Ambient = 1
+	r := Ray{Point(0, 0, 0), Vector(0, 0, 1)}
+	output := w.ReflectedColor(PrepareComputations(Intersection{T: 1, Object: w.Objects[1]}, r, Intersections{Intersection{T: 1, Object: w.Objects[1]}}), DefaultMaxDepth)
+	if !output.Equal(Color(0, 0, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0, 0), output)
+	}
+
+	// reflective material
+	w = DefaultWorld()
+	p := NewPlane()
+	p.Material().Reflective = 0.5
+	p.SetTransform(TranslationMatrix(0, -1, 0))
+	w.Objects = append(w.Objects, p)
+	r = Ray{Point(0, 0, -3), Vector(0, -k, k)}
+	comps := PrepareComputations(Intersection{T: math.Sqrt(2), Object: p}, r, Intersections{Intersection{T: math.Sqrt(2), Object: p}})
+
+	output = w.ReflectedColor(comps, DefaultMaxDepth)
+	if !output.Equal(Color(0.19032, 0.2379, 0.14274), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.19032, 0.2379, 0.14274), output)
+	}
+
+	output = w.ShadeHit(comps, DefaultMaxDepth)
+	if !output.Equal(Color(0.87677, 0.92436, 0.82918), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.87677, 0.92436, 0.82918), output)
+	}
+
+	// no reflections remain
+	output = w.ReflectedColor(comps, 0)
+	if !output.Equal(Color(0, 0, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0, 0), output)
+	}
+
+	// a world with a negative maximum depth renders no reflections
+	w.MaxDepth = -1
+	output = w.ColorAt(r)
+	expected := w.ShadeHit(comps, 0)
+	if !output.Equal(expected, epsilon) {
+		t.Errorf("expected %v, returned %v", expected, output)
+	}
+
+	// a world literal reflects up to the default maximum depth
+	w = World{Objects: w.Objects, Lights: w.Lights}
+	output = w.ColorAt(r)
+	if !output.Equal(Color(0.87677, 0.92436, 0.82918), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0.87677, 0.92436, 0.82918), output)
+	}
+}
+
+func TestMutualReflection(t *testing.T) {
+	lower := NewPlane()
+	lower.Material().Reflective = 1
+	lower.SetTransform(TranslationMatrix(0, -1, 0))
+	upper := NewPlane()
+	upper.Material().Reflective = 1
+	upper.SetTransform(TranslationMatrix(0, 1, 0))
+
+	w := World{
+		Objects:  []Shape{lower, upper},
+		Lights:   []PointLight{{Point(0, 0, 0), Color(1, 1, 1)}},
+		MaxDepth: 100,
+	}
+
+	// terminates, accumulating the ambient light of each bounce
+	output := w.ColorAt(Ray{Point(0, 0, 0), Vector(0, 1, 0)})
+	if output.x() <= 0 {
+		t.Errorf("expected a lit color, returned %v", output)
+	}
+}
+
+// glassSphere returns a sphere with a transparent glass material.
+func glassSphere() *Sphere {
+	s := NewSphere()
+	s.Material().Transparency = 1
+	s.Material().RefractiveIndex = 1.5
+	return s
+}
+
+func TestRefractiveIndices(t *testing.T) {
+	a := glassSphere()
+	a.SetTransform(ScalingMatrix(2, 2, 2))
+	a.Material().RefractiveIndex = 1.5
+	b := glassSphere()
+	b.SetTransform(TranslationMatrix(0, 0, -0.25))
+	b.Material().RefractiveIndex = 2
+	c := glassSphere()
+	c.SetTransform(TranslationMatrix(0, 0, 0.25))
+	c.Material().RefractiveIndex = 2.5
+
+	r := Ray{Point(0, 0, -4), Vector(0, 0, 1)}
+	xs := NewIntersections(
+		Intersection{T: 2, Object: a},
+		Intersection{T: 2.75, Object: b},
+		Intersection{T: 3.25, Object: c},
+		Intersection{T: 4.75, Object: b},
+		Intersection{T: 5.25, Object: c},
+		Intersection{T: 6, Object: a})
+
+	type test struct {
+		n1 float64
+		n2 float64
+	}
+
+	tds := []test{
+		{1.0, 1.5},
+		{1.5, 2.0},
+		{2.0, 2.5},
+		{2.5, 2.5},
+		{2.5, 1.5},
+		{1.5, 1.0},
+	}
+
+	for i, td := range tds {
+		comps := PrepareComputations(xs[i], r, xs)
+		if comps.N1 != td.n1 || comps.N2 != td.n2 {
+			t.Errorf("test %d failed: expected %f %f, returned %f %f", i, td.n1, td.n2, comps.N1, comps.N2)
+		}
+	}
+}
+
+func TestUnderPoint(t *testing.T) {
+	s := glassSphere()
+	s.SetTransform(TranslationMatrix(0, 0, 1))
+
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	i := Intersection{T: 5, Object: s}
+	comps := PrepareComputations(i, r, Intersections{i})
+	if comps.UnderPoint.z() <= Epsilon/2 {
+		t.Errorf("expected under point above %f, returned %f", Epsilon/2, comps.UnderPoint.z())
+	}
+	if comps.Point.z() >= comps.UnderPoint.z() {
+		t.Errorf("expected under point below point, returned %v %v", comps.UnderPoint, comps.Point)
+	}
+}
+
+func TestRefractedColor(t *testing.T) {
+	k := math.Sqrt(2) / 2.
+
+	// opaque surface
+	w := DefaultWorld()
+	s := w.Objects[0]
+	r := Ray{Point(0, 0, -5), Vector(0, 0, 1)}
+	xs := NewIntersections(Intersection{T: 4, Object: s}, Intersection{T: 6, Object: s})
+	output := w.RefractedColor(PrepareComputations(xs[0], r, xs), DefaultMaxDepth)
+	if !output.Equal(Color(0, 0, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0, 0), output)
+	}
+
+	// no refractions remain
+	s.Material().Transparency = 1
+	s.Material().RefractiveIndex = 1.5
+	output = w.RefractedColor(PrepareComputations(xs[0], r, xs), 0)
+	if !output.Equal(Color(0, 0, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0, 0), output)
+	}
+
+	// total internal reflection
+	r = Ray{Point(0, 0, k), Vector(0, 1, 0)}
+	xs = NewIntersections(Intersection{T: -k, Object: s}, Intersection{T: k, Object: s})
+	output = w.RefractedColor(PrepareComputations(xs[1], r, xs), DefaultMaxDepth)
+	if !output.Equal(Color(0, 0, 0), epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0, 0), output)
+	}
+
+	// refracted ray
+	w = DefaultWorld()
+	a := w.Objects[0]
+	a.Material().Ambient = 1
+	a.Material().Pattern = newTestPattern()
+	b := w.Objects[1]
+	b.Material().Transparency = 1
+	b.Material().RefractiveIndex = 1.5
+	r = Ray{Point(0, 0, 0.1), Vector(0, 1, 0)}
+	xs = NewIntersections(
+		Intersection{T: -0.9899, Object: a},
+		Intersection{T: -0.4899, Object: b},
+		Intersection{T: 0.4899, Object: b},
+		Intersection{T: 0.9899, Object: a})
+	output = w.RefractedColor(PrepareComputations(xs[2], r, xs), DefaultMaxDepth)
+	if !output.Equal(Color(0, 0.99888, 0.04725), Epsilon) {
+		t.Errorf("expected %v, returned %v", Color(0, 0.99888, 0.04725), output)
+	}
+}
+
+func TestShadeHitTransparent(t *testing.T) {
+	k := math.Sqrt(2) / 2.
+
+	type test struct {
+		reflective float64
+		expected   Tuple
+	}
+
+	tds := []test{
+		{0, Color(0.93642, 0.68642, 0.68642)},
+		{0.5, Color(0.93391, 0.69643, 0.69243)},
+	}
+
+	for i, td := range tds {
+		w := DefaultWorld()
+		floor := NewPlane()
+		floor.SetTransform(TranslationMatrix(0, -1, 0))
+		floor.Material().Reflective = td.reflective
+		floor.Material().Transparency = 0.5
+		floor.Material().RefractiveIndex = 1.5
+		ball := NewSphere()
+		ball.Material().Color = Color(1, 0, 0)
+		ball.Material().Ambient = 0.5
+		ball.SetTransform(TranslationMatrix(0, -3.5, -0.5))
+		w.Objects = append(w.Objects, floor, ball)
+
+		r := Ray{Point(0, 0, -3), Vector(0, -k, k)}
+		xs := NewIntersections(Intersection{T: math.Sqrt(2), Object: floor})
+		output := w.ShadeHit(PrepareComputations(xs[0], r, xs), DefaultMaxDepth)
+		if !output.Equal(td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %v, returned %v", i, td.expected, output)
+		}
+	}
+}
+
+func TestSchlick(t *testing.T) {
+	k := math.Sqrt(2) / 2.
+	s := glassSphere()
+
+	type test struct {
+		ray      Ray
+		xs       Intersections
+		hit      int
+		expected float64
+	}
+
+	tds := []test{
+		// total internal reflection
+		{Ray{Point(0, 0, k), Vector(0, 1, 0)},
+			NewIntersections(Intersection{T: -k, Object: s}, Intersection{T: k, Object: s}), 1, 1},
+		// perpendicular viewing angle
+		{Ray{Point(0, 0, 0), Vector(0, 1, 0)},
+			NewIntersections(Intersection{T: -1, Object: s}, Intersection{T: 1, Object: s}), 1, 0.04},
+		// small angle with n2 > n1
+		{Ray{Point(0, 0.99, -2), Vector(0, 0, 1)},
+			NewIntersections(Intersection{T: 1.8589, Object: s}), 0, 0.48873},
+	}
+
+	for i, td := range tds {
+		output := Schlick(PrepareComputations(td.xs[td.hit], td.ray, td.xs))
+		if !eq(output, td.expected, Epsilon) {
+			t.Errorf("test %d failed: expected %f, returned %f", i, td.expected, output)
+		}
+	}
+}